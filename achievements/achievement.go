@@ -0,0 +1,49 @@
+package achievements
+
+import (
+	"time"
+)
+
+// Available achievement metrics.
+const (
+	// MetricTotalPoint measures score's total point.
+	MetricTotalPoint = "total_point"
+	// MetricLongestStreak measures score's longest streak.
+	MetricLongestStreak = "longest_streak"
+	// MetricPointCount measures number of points earned with the given point name.
+	MetricPointCount = "point_count"
+)
+
+const (
+	// EventAchievementUnlocked is published when a user unlocks an achievement.
+	EventAchievementUnlocked = "achievement.unlocked"
+)
+
+var (
+	// Definitions of available achievements.
+	Definitions = []Achievement{
+		{Code: "first_10_todos", Name: "First 10 Todos", Description: "Complete 10 todos.", Metric: MetricPointCount, Point: "todo completed", Goal: 10},
+		{Code: "100_points", Name: "100 Points", Description: "Earn 100 points.", Metric: MetricTotalPoint, Goal: 100},
+		{Code: "7_day_streak", Name: "7-Day Streak", Description: "Complete a todo 7 days in a row.", Metric: MetricLongestStreak, Goal: 7},
+	}
+)
+
+// Achievement is unlocked once the metric reaches the goal.
+type Achievement struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Metric      string `json:"metric"`
+	// Point name counted by point_count metric.
+	Point string `json:"point,omitempty"`
+	Goal  int    `json:"goal"`
+}
+
+// Progress of an achievement for a user.
+type Progress struct {
+	Achievement
+	// Progress toward the goal, capped at goal.
+	Progress   int        `json:"progress"`
+	Unlocked   bool       `json:"unlocked"`
+	UnlockedAt *time.Time `json:"unlocked_at"`
+}
@@ -0,0 +1,35 @@
+package achievementstest
+
+import (
+	achievements "github.com/go-rel/gin-example/achievements"
+	scores "github.com/go-rel/gin-example/scores"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock achievement functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockEarned util.
+func MockEarned(score scores.Score, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Earned", mock.Anything, score).
+			Return(err)
+	}
+}
+
+// MockList util.
+func MockList(result []achievements.Progress, score scores.Score, err error) MockFunc {
+	return func(service *Service) {
+		service.On("List", mock.Anything, score).
+			Return(result, err)
+	}
+}
@@ -0,0 +1,37 @@
+package achievements
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/scores"
+)
+
+type list struct {
+	unlock
+}
+
+// List all achievements with progress of the score's owner.
+func (l list) List(ctx context.Context, score scores.Score) ([]Progress, error) {
+	unlocked, err := l.unlocked(ctx, score.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Progress, len(l.definitions))
+	for i, achievement := range l.definitions {
+		result[i].Achievement = achievement
+
+		if record, ok := unlocked[achievement.Code]; ok {
+			result[i].Progress = achievement.Goal
+			result[i].Unlocked = true
+			result[i].UnlockedAt = &record.CreatedAt
+			continue
+		}
+
+		if result[i].Progress, err = l.progress(ctx, achievement, score); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
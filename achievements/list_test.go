@@ -0,0 +1,72 @@
+package achievements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, events.Nop{})
+		unlockedAt = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+		score      = scores.Score{ID: 1, UserID: 1, TotalPoint: 150, LongestStreak: 3}
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1)).Result([]UnlockedAchievement{
+		{ID: 1, UserID: 1, Code: "100_points", CreatedAt: unlockedAt},
+	})
+	repository.ExpectCount("points", where.Eq("score_id", 1).AndEq("name", "todo completed")).Result(4)
+
+	result, err := service.List(ctx, score)
+	assert.Nil(t, err)
+	assert.Equal(t, []Progress{
+		{Achievement: Definitions[0], Progress: 4},
+		{Achievement: Definitions[1], Progress: 100, Unlocked: true, UnlockedAt: &unlockedAt},
+		{Achievement: Definitions[2], Progress: 3},
+	}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestList_withoutScore(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, events.Nop{})
+		score      = scores.Score{UserID: 1}
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1)).Result([]UnlockedAchievement{})
+
+	result, err := service.List(ctx, score)
+	assert.Nil(t, err)
+	assert.Equal(t, []Progress{
+		{Achievement: Definitions[0]},
+		{Achievement: Definitions[1]},
+		{Achievement: Definitions[2]},
+	}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestList_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, events.Nop{})
+		score      = scores.Score{ID: 1, UserID: 1}
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1)).ConnectionClosed()
+
+	_, err := service.List(ctx, score)
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package achievements
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+)
+
+//go:generate mockery --name=Service --case=underscore --output achievementstest --outpkg achievementstest
+
+// Service instance for achievement's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	scores.Listener
+	List(ctx context.Context, score scores.Score) ([]Progress, error)
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	unlock
+	list
+}
+
+var _ Service = (*service)(nil)
+
+// New Achievements service, publisher is notified every time an achievement is unlocked.
+func New(repository rel.Repository, publisher events.Publisher) Service {
+	unlock := unlock{repository: repository, publisher: publisher, definitions: Definitions}
+
+	return service{
+		unlock: unlock,
+		list:   list{unlock: unlock},
+	}
+}
@@ -0,0 +1,103 @@
+package achievements
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type unlock struct {
+	repository  rel.Repository
+	publisher   events.Publisher
+	definitions []Achievement
+}
+
+// Earned unlocks every achievement that reaches its goal after the user earns points.
+func (u unlock) Earned(ctx context.Context, score scores.Score) error {
+	unlocked, err := u.unlocked(ctx, score.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, achievement := range u.definitions {
+		if _, ok := unlocked[achievement.Code]; ok {
+			continue
+		}
+
+		progress, err := u.progress(ctx, achievement, score)
+		if err != nil {
+			return err
+		}
+
+		if progress < achievement.Goal {
+			continue
+		}
+
+		result := UnlockedAchievement{UserID: score.UserID, Code: achievement.Code}
+		if err := u.repository.Insert(ctx, &result); err != nil {
+			if errors.Is(err, rel.ErrUniqueConstraint) {
+				// already granted by concurrent request.
+				continue
+			}
+
+			return err
+		}
+
+		// unlocked achievement is only announced once the earn transaction commits.
+		events.AfterCommit(ctx, u.publisher, events.Event{
+			Name:   EventAchievementUnlocked,
+			UserID: score.UserID,
+			Data:   achievement,
+		})
+	}
+
+	return nil
+}
+
+// unlocked achievements of a user indexed by code.
+func (u unlock) unlocked(ctx context.Context, userID int) (map[string]UnlockedAchievement, error) {
+	var (
+		result   []UnlockedAchievement
+		unlocked = make(map[string]UnlockedAchievement)
+	)
+
+	if err := u.repository.FindAll(ctx, &result, where.Eq("user_id", userID)); err != nil {
+		return nil, err
+	}
+
+	for i := range result {
+		unlocked[result[i].Code] = result[i]
+	}
+
+	return unlocked, nil
+}
+
+// progress of an achievement, may not exceed the goal.
+func (u unlock) progress(ctx context.Context, achievement Achievement, score scores.Score) (int, error) {
+	var (
+		progress int
+		err      error
+	)
+
+	switch achievement.Metric {
+	case MetricTotalPoint:
+		progress = score.TotalPoint
+	case MetricLongestStreak:
+		progress = score.LongestStreak
+	case MetricPointCount:
+		// user without score can't have any point yet.
+		if score.ID != 0 {
+			progress, err = u.repository.Count(ctx, "points", where.Eq("score_id", score.ID).AndEq("name", achievement.Point))
+		}
+	}
+
+	if progress > achievement.Goal {
+		progress = achievement.Goal
+	}
+
+	return progress, err
+}
@@ -0,0 +1,138 @@
+package achievements
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarned(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    scores.Score
+		unlocked []UnlockedAchievement
+		mockRepo func(repository *reltest.Repository)
+		events   []string
+	}{
+		{
+			name:     "total point",
+			score:    scores.Score{ID: 1, UserID: 1, TotalPoint: 120, LongestStreak: 3},
+			unlocked: []UnlockedAchievement{{ID: 1, UserID: 1, Code: "first_10_todos"}},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectInsert().For(&UnlockedAchievement{UserID: 1, Code: "100_points"})
+			},
+			events: []string{"100_points"},
+		},
+		{
+			name:  "point count and streak",
+			score: scores.Score{ID: 1, UserID: 1, TotalPoint: 20, LongestStreak: 7},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectCount("points", where.Eq("score_id", 1).AndEq("name", "todo completed")).Result(12)
+				repository.ExpectInsert().For(&UnlockedAchievement{UserID: 1, Code: "first_10_todos"})
+				repository.ExpectInsert().For(&UnlockedAchievement{UserID: 1, Code: "7_day_streak"})
+			},
+			events: []string{"first_10_todos", "7_day_streak"},
+		},
+		{
+			name:  "locked",
+			score: scores.Score{ID: 1, UserID: 1, TotalPoint: 20, LongestStreak: 2},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectCount("points", where.Eq("score_id", 1).AndEq("name", "todo completed")).Result(9)
+			},
+		},
+		{
+			name:  "granted concurrently",
+			score: scores.Score{ID: 1, UserID: 1, TotalPoint: 100, LongestStreak: 2},
+			unlocked: []UnlockedAchievement{
+				{ID: 1, UserID: 1, Code: "first_10_todos"},
+			},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectInsert().For(&UnlockedAchievement{UserID: 1, Code: "100_points"}).NotUnique("user_id_code")
+			},
+		},
+		{
+			name:  "all unlocked",
+			score: scores.Score{ID: 1, UserID: 1, TotalPoint: 200, LongestStreak: 10},
+			unlocked: []UnlockedAchievement{
+				{ID: 1, UserID: 1, Code: "first_10_todos"},
+				{ID: 2, UserID: 1, Code: "100_points"},
+				{ID: 3, UserID: 1, Code: "7_day_streak"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				bus        = events.NewBus()
+				service    = New(repository, bus)
+				published  []string
+			)
+
+			bus.Subscribe(func(ctx context.Context, event events.Event) {
+				assert.Equal(t, EventAchievementUnlocked, event.Name)
+				assert.Equal(t, test.score.UserID, event.UserID)
+				published = append(published, event.Data.(Achievement).Code)
+			})
+
+			repository.ExpectFindAll(where.Eq("user_id", 1)).Result(test.unlocked)
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			assert.Nil(t, service.Earned(ctx, test.score))
+			assert.Equal(t, test.events, published)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEarned_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, events.Nop{})
+		score      = scores.Score{ID: 1, UserID: 1, TotalPoint: 120}
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1)).Result([]UnlockedAchievement{})
+	repository.ExpectCount("points", where.Eq("score_id", 1).AndEq("name", "todo completed")).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Earned(ctx, score))
+	repository.AssertExpectations(t)
+}
+
+func TestEarned_rolledBack(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		bus        = events.NewBus()
+		service    = New(repository, bus)
+		score      = scores.Score{ID: 1, UserID: 1, TotalPoint: 120, LongestStreak: 3}
+		err        = errors.New("earn error")
+		published  int
+	)
+
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		published++
+	})
+
+	repository.ExpectFindAll(where.Eq("user_id", 1)).Result([]UnlockedAchievement{{ID: 1, UserID: 1, Code: "first_10_todos"}})
+	repository.ExpectInsert().For(&UnlockedAchievement{UserID: 1, Code: "100_points"})
+
+	// unlocked achievement is discarded together with the earn transaction.
+	assert.Equal(t, err, events.Commit(ctx, func(ctx context.Context) error {
+		assert.Nil(t, service.Earned(ctx, score))
+		return err
+	}))
+	assert.Zero(t, published)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,14 @@
+package achievements
+
+import (
+	"time"
+)
+
+// UnlockedAchievement records an achievement granted to a user, each achievement is only granted once.
+type UnlockedAchievement struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
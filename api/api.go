@@ -1,41 +1,118 @@
 package api
 
 import (
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/requestid"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/graphql"
 	"github.com/go-rel/gin-example/api/handler"
-	"github.com/go-rel/gin-example/scores"
-	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/openapi"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/services"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
 )
 
-// New api.
-func New(repository rel.Repository) *gin.Engine {
+var (
+	// v1Deprecation is when v1 is deprecated in favor of v2.
+	v1Deprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	// v1Sunset is when v1 and unversioned routes are going to be removed.
+	v1Sunset = time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC)
+)
+
+// New api serving the services, events stream sends heartbeat every EVENTS_HEARTBEAT.
+// Requests are rate limited using limits loaded from RATE_LIMIT_CONFIG, client ip is only read from
+// forwarded headers set by proxies listed in TRUSTED_PROXIES.
+func New(repository rel.Repository, services services.Services) *gin.Engine {
+	spec, err := openapi.Load(openapi.Document())
+	if err != nil {
+		panic(err)
+	}
+
+	limits, err := middleware.LoadLimits(os.Getenv("RATE_LIMIT_CONFIG"))
+	if err != nil {
+		panic(err)
+	}
+
+	// default heartbeat is used when it's empty or invalid.
+	heartbeat, _ := time.ParseDuration(os.Getenv("EVENTS_HEARTBEAT"))
+
 	var (
-		logger, _      = zap.NewProduction()
-		router         = gin.New()
-		scores         = scores.New(repository)
-		todos          = todos.New(repository, scores)
-		healthzHandler = handler.NewHealthz()
-		todosHandler   = handler.NewTodos(repository, todos)
-		scoreHandler   = handler.NewScore(repository)
+		logger, _           = zap.NewProduction()
+		router              = gin.New()
+		healthzHandler      = handler.NewHealthz()
+		openAPIHandler      = handler.NewOpenAPI(openapi.Document())
+		todosHandler        = handler.NewTodos(repository, services.Todos)
+		scoreHandler        = handler.NewScore(services.Scores)
+		achievementsHandler = handler.NewAchievements(services.Scores, services.Achievements)
+		rewardsHandler      = handler.NewRewards(repository, services.Rewards)
+		challengesHandler   = handler.NewChallenges(repository, services.Challenges)
+		webhooksHandler     = handler.NewWebhooks(repository, services.Webhooks)
+		graphqlHandler      = handler.NewGraphQL(graphql.New(repository, services.Todos, services.Scores))
+		eventsHandler       = handler.NewEvents(services.Stream, heartbeat)
+		store               = middleware.NewMemoryStore(middleware.DefaultStoreSize)
+		limiter             = middleware.Limiter{Store: store, Limits: limits}
+		webSocketHandler    = handler.NewWebSocket(repository, services.Todos, services.Stream, limiter, handler.DefaultWebSocketQueue)
+		rateLimit           = middleware.RateLimit(store, limits)
+		validate            = middleware.Validate(spec)
+		negotiate           = middleware.Negotiate(middleware.ListRoutes...)
 	)
 
+	if err := router.SetTrustedProxies(trustedProxies(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		panic(err)
+	}
+
 	healthzHandler.Add("database", repository)
 
 	router.Use(ginzap.Ginzap(logger, time.RFC3339, true))
-	router.Use(ginzap.RecoveryWithZap(logger, true))
+	router.Use(ginzap.CustomRecoveryWithZap(logger, true, problem.Recovery))
 	router.Use(requestid.New())
 	router.Use(cors.Default())
 
-	healthzHandler.Mount(router.Group("/healthz"))
-	todosHandler.Mount(router.Group("/todos"))
-	scoreHandler.Mount(router.Group("/score"))
+	// events stream and websocket are mounted without format negotiation, since they don't respond in negotiated format.
+	eventsHandler.Mount(router.Group("/events", middleware.Auth, rateLimit))
+	webSocketHandler.Mount(router.Group("/ws", middleware.Auth, rateLimit))
+
+	router.NoRoute(problem.NotFound)
+
+	// rate limit runs after auth, so authenticated requests are limited by user rather than ip.
+	// requests are validated once they're authenticated, so unauthenticated request learns nothing about the api.
+	// format is negotiated within the groups once the version is known, so csv is only allowed on list routes.
+	healthzHandler.Mount(router.Group("/healthz", negotiate, rateLimit, validate))
+	openAPIHandler.Mount(router.Group("/", negotiate, rateLimit, validate))
+	graphqlHandler.Mount(router.Group("/graphql", negotiate, middleware.Auth, rateLimit, validate))
+
+	mount := func(router *gin.RouterGroup) {
+		todosHandler.Mount(router.Group("/todos", negotiate, middleware.Auth, rateLimit, validate))
+		scoreHandler.Mount(router.Group("/score", negotiate, middleware.Auth, rateLimit, validate))
+		achievementsHandler.Mount(router.Group("/score/achievements", negotiate, middleware.Auth, rateLimit, validate))
+		rewardsHandler.Mount(router.Group("/rewards", negotiate, middleware.Auth, rateLimit, validate))
+		challengesHandler.Mount(router.Group("/challenges", negotiate, middleware.Auth, rateLimit, validate))
+		webhooksHandler.Mount(router.Group("/webhooks", negotiate, middleware.Auth, rateLimit, validate))
+	}
+
+	// unversioned routes are aliases of v1 kept for clients from before versioning.
+	mount(router.Group("/", middleware.Version(""), middleware.Deprecate("", "/v2", v1Deprecation, v1Sunset)))
+	mount(router.Group("/v1", middleware.Version(middleware.VersionV1), middleware.Deprecate("/v1", "/v2", v1Deprecation, v1Sunset)))
+	mount(router.Group("/v2", middleware.Version(middleware.VersionV2)))
 
 	return router
 }
+
+// trustedProxies parses comma separated list of proxy ips or cidrs, no proxy is trusted when it's empty.
+func trustedProxies(str string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(str, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
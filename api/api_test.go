@@ -0,0 +1,178 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-rel/gin-example/api"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/openapi"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_routesDocumented(t *testing.T) {
+	var (
+		repository = reltest.New()
+		router     = api.New(repository, services.New(repository))
+		spec, _    = openapi.Load(openapi.Document())
+		mounted    = make(map[string]bool)
+	)
+
+	for _, route := range router.Routes() {
+		_, _, found := spec.Find(route.Method, route.Path)
+		assert.True(t, found, "%s %s is missing from openapi.json", route.Method, route.Path)
+
+		mounted[route.Method+" "+strings.TrimSuffix(route.Path, "/")] = true
+	}
+
+	for _, template := range spec.Templates() {
+		path := template
+		for _, segment := range strings.Split(template, "/") {
+			if name, ok := strings.CutPrefix(segment, "{"); ok {
+				path = strings.Replace(path, segment, ":"+strings.TrimSuffix(name, "}"), 1)
+			}
+		}
+
+		for _, prefix := range spec.Prefixes(template) {
+			for _, method := range spec.Methods(template) {
+				assert.True(t, mounted[method+" "+prefix+path], "%s %s%s is documented but not mounted", method, prefix, template)
+			}
+		}
+	}
+
+	for _, list := range middleware.ListRoutes {
+		method, path, _ := strings.Cut(list, " ")
+		assert.True(t, mounted[method+" /v2"+path], "list route %s is not mounted", list)
+	}
+}
+
+func TestNew_versions(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		deprecation string
+		sunset      string
+		link        string
+	}{
+		{
+			name:        "unversioned",
+			path:        "/todos/",
+			deprecation: "@1792368000",
+			sunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+			link:        `</v2/todos/>; rel="successor-version"`,
+		},
+		{
+			name:        "v1",
+			path:        "/v1/todos/",
+			deprecation: "@1792368000",
+			sunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+			link:        `</v2/todos/>; rel="successor-version"`,
+		},
+		{
+			name: "v2",
+			path: "/v2/todos/",
+		},
+	}
+
+	var (
+		repository = reltest.New()
+		router     = api.New(repository, services.New(repository))
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _ = http.NewRequest("GET", test.path, nil)
+				rr     = httptest.NewRecorder()
+			)
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			assert.Equal(t, test.deprecation, rr.Header().Get("Deprecation"))
+			assert.Equal(t, test.sunset, rr.Header().Get("Sunset"))
+			assert.Equal(t, test.link, rr.Header().Get("Link"))
+		})
+	}
+}
+
+func TestNew_events(t *testing.T) {
+	var (
+		repository = reltest.New()
+		services   = services.New(repository)
+		router     = api.New(repository, services)
+		req, _     = http.NewRequest("GET", "/events", nil)
+		rr         = httptest.NewRecorder()
+	)
+
+	// stream ends immediately once closed.
+	services.Stream.Close()
+
+	// EventSource only accepts text/event-stream, which isn't a negotiated format.
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-User-ID", "1")
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+}
+
+func TestNew_rateLimit(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "limits.json")
+	)
+
+	os.WriteFile(path, []byte(`{"GET /healthz": {"requests": 1, "period": "1m"}}`), 0o600)
+	t.Setenv("RATE_LIMIT_CONFIG", path)
+
+	var (
+		repository = reltest.New()
+		router     = api.New(repository, services.New(repository))
+	)
+
+	for _, status := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		var (
+			req, _ = http.NewRequest("GET", "/healthz/", nil)
+			rr     = httptest.NewRecorder()
+		)
+
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, status, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestNew_validateAfterAuth(t *testing.T) {
+	var (
+		repository = reltest.New()
+		router     = api.New(repository, services.New(repository))
+		req, _     = http.NewRequest("POST", "/v2/todos/", strings.NewReader(`{"title":1}`))
+		rr         = httptest.NewRecorder()
+	)
+
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestNew_csvMutation(t *testing.T) {
+	var (
+		repository = reltest.New()
+		router     = api.New(repository, services.New(repository))
+		req, _     = http.NewRequest("POST", "/v2/todos/?format=csv", strings.NewReader(`{"title":"Sleep"}`))
+		rr         = httptest.NewRecorder()
+	)
+
+	req.Header.Set("X-User-ID", "1")
+	router.ServeHTTP(rr, req)
+
+	// rejected before the todo is created, reltest panics on unexpected insert.
+	assert.Equal(t, http.StatusNotAcceptable, rr.Code)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,310 @@
+package format
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// object is a decoded json object that keeps the order of its members.
+type object []member
+
+type member struct {
+	key   string
+	value interface{}
+}
+
+// decode json representation of body, objects are decoded as object and numbers as json.Number.
+func decode(body interface{}) (interface{}, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decodeValue(decoder)
+}
+
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		var result object
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, member{key: key.(string), value: value})
+		}
+
+		_, err = decoder.Token()
+		return result, err
+	case json.Delim('['):
+		result := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, value)
+		}
+
+		_, err = decoder.Token()
+		return result, err
+	default:
+		return token, nil
+	}
+}
+
+// encodeXML value as children of response element, list items are encoded as item elements.
+func encodeXML(w io.Writer, value interface{}) error {
+	encoder := xml.NewEncoder(w)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	if err := encodeXMLElement(encoder, "response", value); err != nil {
+		return err
+	}
+
+	return encoder.Flush()
+}
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case object:
+		for _, m := range v {
+			if err := encodeXMLElement(encoder, m.key, m.value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if err := encodeXMLElement(encoder, "item", v[i]); err != nil {
+				return err
+			}
+		}
+	case nil:
+	default:
+		if err := encoder.EncodeToken(xml.CharData(scalar(v))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// yamlNode of value, yaml node is used instead of map to keep the order of object members.
+func yamlNode(value interface{}) *yaml.Node {
+	switch v := value.(type) {
+	case object:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, m := range v {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: m.key}, yamlNode(m.value))
+		}
+
+		return node
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for i := range v {
+			node.Content = append(node.Content, yamlNode(v[i]))
+		}
+
+		return node
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: v.String()}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: v.String()}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: scalar(v)}
+	}
+}
+
+// plain value for msgpack encoder, order of object members is not significant in msgpack.
+func plain(value interface{}) interface{} {
+	switch v := value.(type) {
+	case object:
+		result := make(map[string]interface{}, len(v))
+		for _, m := range v {
+			result[m.key] = plain(m.value)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i := range v {
+			result[i] = plain(v[i])
+		}
+
+		return result
+	case json.Number:
+		if number, err := v.Int64(); err == nil {
+			return number
+		}
+
+		number, _ := v.Float64()
+		return number
+	default:
+		return v
+	}
+}
+
+// encodeCSV list of objects with a header row, columns are ordered by first appearance.
+// Nested lists and objects are encoded as json in a single column.
+func encodeCSV(w io.Writer, value interface{}) error {
+	list, ok := value.([]interface{})
+	if !ok {
+		return ErrFormatNotList
+	}
+
+	var (
+		columns []string
+		index   = make(map[string]int)
+		rows    = make([]map[string]string, len(list))
+	)
+
+	for i := range list {
+		item, ok := list[i].(object)
+		if !ok {
+			return ErrFormatNotList
+		}
+
+		rows[i] = make(map[string]string, len(item))
+		for _, m := range item {
+			if _, ok := index[m.key]; !ok {
+				index[m.key] = len(columns)
+				columns = append(columns, m.key)
+			}
+
+			cell, err := csvCell(m.value)
+			if err != nil {
+				return err
+			}
+
+			rows[i][m.key] = cell
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	for i := range rows {
+		record := make([]string, len(columns))
+		for j, column := range columns {
+			record[j] = rows[i][column]
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func csvCell(value interface{}) (string, error) {
+	switch value.(type) {
+	case object, []interface{}:
+		var buf bytes.Buffer
+		if err := encodeJSON(&buf, value); err != nil {
+			return "", err
+		}
+
+		return buf.String(), nil
+	case nil:
+		return "", nil
+	default:
+		return scalar(value), nil
+	}
+}
+
+// encodeJSON decoded value back to json, used for nested values of csv.
+func encodeJSON(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case object:
+		buf.WriteByte('{')
+		for i, m := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(m.key)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := encodeJSON(buf, m.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := encodeJSON(buf, v[i]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(data)
+	}
+
+	return nil
+}
+
+// scalar value as string.
+func scalar(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
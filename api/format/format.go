@@ -0,0 +1,140 @@
+// Package format negotiates and encodes response format.
+// Every format is encoded from the json representation of the body, so custom json marshaller such as todo's url
+// and json field names are kept in every format.
+package format
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Supported formats, as accepted by ?format= query.
+const (
+	JSON    = "json"
+	XML     = "xml"
+	YAML    = "yaml"
+	MsgPack = "msgpack"
+	CSV     = "csv"
+)
+
+const formatKey = "formatKey"
+
+var (
+	// ErrFormatUnsupported error.
+	ErrFormatUnsupported = errors.New("Format must be one of json, xml, yaml, msgpack or csv")
+	// ErrFormatNotList error.
+	ErrFormatNotList = errors.New("CSV format is only supported for list responses")
+)
+
+// mediaTypes of formats in order of preference when the client accepts any of them.
+var mediaTypes = []struct {
+	format    string
+	mediaType string
+}{
+	{format: JSON, mediaType: "application/json"},
+	{format: XML, mediaType: "application/xml"},
+	{format: XML, mediaType: "text/xml"},
+	{format: YAML, mediaType: "application/yaml"},
+	{format: YAML, mediaType: "application/x-yaml"},
+	{format: YAML, mediaType: "text/yaml"},
+	{format: MsgPack, mediaType: "application/msgpack"},
+	{format: MsgPack, mediaType: "application/x-msgpack"},
+	{format: CSV, mediaType: "text/csv"},
+}
+
+// Negotiate response format using ?format= query, or Accept header when the query is not specified.
+// JSON is used when neither is specified.
+func Negotiate(c *gin.Context) (string, error) {
+	if format := c.Query("format"); format != "" {
+		for _, mt := range mediaTypes {
+			if mt.format == format {
+				return format, nil
+			}
+		}
+
+		return "", ErrFormatUnsupported
+	}
+
+	accept := c.GetHeader("Accept")
+	if accept == "" {
+		return JSON, nil
+	}
+
+	for _, mediaRange := range accepted(accept) {
+		for _, mt := range mediaTypes {
+			if matchMediaRange(mediaRange, mt.mediaType) {
+				return mt.format, nil
+			}
+		}
+	}
+
+	return "", ErrFormatUnsupported
+}
+
+// Set negotiated format of the request.
+func Set(c *gin.Context, format string) {
+	c.Set(formatKey, format)
+}
+
+// get format of the request, negotiates when it's not set.
+func get(c *gin.Context) (string, error) {
+	if format := c.GetString(formatKey); format != "" {
+		return format, nil
+	}
+
+	return Negotiate(c)
+}
+
+// accepted media ranges of Accept header ordered by quality, media ranges with zero quality are excluded.
+func accepted(accept string) []string {
+	type mediaRange struct {
+		value   string
+		quality float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		var (
+			params  = strings.Split(part, ";")
+			value   = strings.ToLower(strings.TrimSpace(params[0]))
+			quality = 1.0
+		)
+
+		for _, param := range params[1:] {
+			if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+
+		if value != "" && quality > 0 {
+			ranges = append(ranges, mediaRange{value: value, quality: quality})
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	result := make([]string, len(ranges))
+	for i := range ranges {
+		result[i] = ranges[i].value
+	}
+
+	return result
+}
+
+// matchMediaRange returns true if media type is matched by media range, eg. text/* matches text/csv.
+func matchMediaRange(mediaRange string, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+
+	prefix, ok := strings.CutSuffix(mediaRange, "*")
+	return ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(mediaType, prefix)
+}
@@ -0,0 +1,221 @@
+package format_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/ugorji/go/codec"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		accept string
+		format string
+		err    error
+	}{
+		{
+			name:   "default",
+			path:   "/",
+			format: format.JSON,
+		},
+		{
+			name:   "query",
+			path:   "/?format=yaml",
+			accept: "application/json",
+			format: format.YAML,
+		},
+		{
+			name: "unsupported query",
+			path: "/?format=pdf",
+			err:  format.ErrFormatUnsupported,
+		},
+		{
+			name:   "accept",
+			path:   "/",
+			accept: "text/csv",
+			format: format.CSV,
+		},
+		{
+			name:   "accept with parameters",
+			path:   "/",
+			accept: "application/msgpack; charset=utf-8",
+			format: format.MsgPack,
+		},
+		{
+			name:   "accept quality",
+			path:   "/",
+			accept: "application/json;q=0.5, application/xml",
+			format: format.XML,
+		},
+		{
+			name:   "accept browser",
+			path:   "/",
+			accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			format: format.XML,
+		},
+		{
+			name:   "accept wildcard",
+			path:   "/",
+			accept: "*/*",
+			format: format.JSON,
+		},
+		{
+			name:   "accept type wildcard",
+			path:   "/",
+			accept: "text/*",
+			format: format.XML,
+		},
+		{
+			name:   "accept zero quality",
+			path:   "/",
+			accept: "application/json;q=0, text/yaml",
+			format: format.YAML,
+		},
+		{
+			name:   "unsupported accept",
+			path:   "/",
+			accept: "application/pdf",
+			err:    format.ErrFormatUnsupported,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				c, _ = gin.CreateTestContext(httptest.NewRecorder())
+			)
+
+			c.Request = httptest.NewRequest("GET", test.path, nil)
+			c.Request.Header.Set("Accept", test.accept)
+
+			result, err := format.Negotiate(c)
+			assert.Equal(t, test.format, result)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
+
+type item struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+	Note *string  `json:"note"`
+}
+
+func (i item) MarshalJSON() ([]byte, error) {
+	type alias item
+	return json.Marshal(struct {
+		alias
+		URL string `json:"url"`
+	}{
+		alias: alias(i),
+		URL:   fmt.Sprint("items/", i.ID),
+	})
+}
+
+func TestRender(t *testing.T) {
+	var (
+		items = []item{{ID: 1, Name: "Sleep & rest", Tags: []string{"home", "night"}}, {ID: 2, Name: "Wake"}}
+	)
+
+	tests := []struct {
+		name        string
+		format      string
+		body        interface{}
+		contentType string
+		response    string
+		err         error
+	}{
+		{
+			name:        "json",
+			format:      format.JSON,
+			body:        items,
+			contentType: "application/json; charset=utf-8",
+			response:    `[{"id":1,"name":"Sleep \u0026 rest","tags":["home","night"],"note":null,"url":"items/1"},{"id":2,"name":"Wake","tags":null,"note":null,"url":"items/2"}]`,
+		},
+		{
+			name:        "xml",
+			format:      format.XML,
+			body:        items[0],
+			contentType: format.ContentTypeXML,
+			response:    `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><id>1</id><name>Sleep &amp; rest</name><tags><item>home</item><item>night</item></tags><note></note><url>items/1</url></response>`,
+		},
+		{
+			name:        "yaml",
+			format:      format.YAML,
+			body:        items,
+			contentType: format.ContentTypeYAML,
+			response:    "- id: 1\n  name: Sleep & rest\n  tags:\n    - home\n    - night\n  note: null\n  url: items/1\n- id: 2\n  name: Wake\n  tags: null\n  note: null\n  url: items/2\n",
+		},
+		{
+			name:        "csv",
+			format:      format.CSV,
+			body:        items,
+			contentType: format.ContentTypeCSV,
+			response:    "id,name,tags,note,url\n1,Sleep & rest,\"[\"\"home\"\",\"\"night\"\"]\",,items/1\n2,Wake,,,items/2\n",
+		},
+		{
+			name:        "csv empty list",
+			format:      format.CSV,
+			body:        []item{},
+			contentType: format.ContentTypeCSV,
+			response:    "",
+		},
+		{
+			name:   "csv not a list",
+			format: format.CSV,
+			body:   items[0],
+			err:    format.ErrFormatNotList,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				rr   = httptest.NewRecorder()
+				c, _ = gin.CreateTestContext(rr)
+			)
+
+			c.Request = httptest.NewRequest("GET", "/", nil)
+			format.Set(c, test.format)
+
+			err := format.Render(c, http.StatusOK, test.body)
+			assert.Equal(t, test.err, err)
+
+			if test.err == nil {
+				assert.Equal(t, http.StatusOK, rr.Code)
+				assert.Equal(t, test.contentType, rr.Header().Get("Content-Type"))
+				assert.Equal(t, "Accept", rr.Header().Get("Vary"))
+				assert.Equal(t, test.response, rr.Body.String())
+			} else {
+				assert.Empty(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRender_msgpack(t *testing.T) {
+	var (
+		rr     = httptest.NewRecorder()
+		c, _   = gin.CreateTestContext(rr)
+		result []map[string]interface{}
+	)
+
+	c.Request = httptest.NewRequest("GET", "/?format=msgpack", nil)
+
+	assert.Nil(t, format.Render(c, http.StatusOK, []item{{ID: 1, Name: "Sleep"}}))
+	assert.Equal(t, format.ContentTypeMsgPack, rr.Header().Get("Content-Type"))
+	handle := codec.MsgpackHandle{}
+	handle.RawToString = true
+
+	assert.Nil(t, codec.NewDecoderBytes(rr.Body.Bytes(), &handle).Decode(&result))
+	assert.Equal(t, []map[string]interface{}{{"id": int64(1), "name": "Sleep", "tags": nil, "note": nil, "url": "items/1"}}, result)
+}
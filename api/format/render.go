@@ -0,0 +1,78 @@
+package format
+
+import (
+	"bytes"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// Content types of formats other than json.
+const (
+	ContentTypeXML     = "application/xml; charset=utf-8"
+	ContentTypeYAML    = "application/yaml; charset=utf-8"
+	ContentTypeMsgPack = "application/msgpack"
+	ContentTypeCSV     = "text/csv; charset=utf-8"
+)
+
+// Render body in the negotiated format.
+// Nothing is written when error is returned, so the caller can still respond with the error.
+func Render(c *gin.Context, status int, body interface{}) error {
+	format, err := get(c)
+	if err != nil {
+		return err
+	}
+
+	c.Header("Vary", "Accept")
+
+	if format == JSON {
+		c.JSON(status, body)
+		return nil
+	}
+
+	value, err := decode(body)
+	if err != nil {
+		return err
+	}
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+	)
+
+	switch format {
+	case XML:
+		contentType, err = ContentTypeXML, encodeXML(&buf, value)
+	case YAML:
+		contentType, err = ContentTypeYAML, encodeYAML(&buf, value)
+	case MsgPack:
+		contentType, err = ContentTypeMsgPack, encodeMsgPack(&buf, value)
+	case CSV:
+		contentType, err = ContentTypeCSV, encodeCSV(&buf, value)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.Data(status, contentType, buf.Bytes())
+	return nil
+}
+
+func encodeYAML(buf *bytes.Buffer, value interface{}) error {
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(yamlNode(value)); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// encodeMsgPack using the current msgpack spec, which has distinct str and bin types.
+func encodeMsgPack(buf *bytes.Buffer, value interface{}) error {
+	handle := codec.MsgpackHandle{WriteExt: true}
+	return codec.NewEncoder(buf, &handle).Encode(plain(value))
+}
@@ -0,0 +1,94 @@
+// Package graphql serves a GraphQL schema over todos and scores.
+// Operations are parsed, validated and executed by graph-gophers/graphql-go, including introspection,
+// this package only contains the resolvers and limits the depth and complexity of an operation before it's executed.
+package graphql
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/api/problem"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "graphql")))
+)
+
+// Default limits of a query.
+const (
+	DefaultMaxDepth      = 6
+	DefaultMaxComplexity = 500
+	// DefaultListSize is the estimated size of a list field without limit argument, used to calculate complexity.
+	DefaultListSize = 10
+)
+
+// Schema of the api.
+type Schema struct {
+	MaxComplexity int
+
+	// engine executes the operation and rejects operation deeper than DefaultMaxDepth when it's validated.
+	engine *graphqlgo.Schema
+	// analysis is the same schema loaded by gqlparser, which exposes the parsed operation to calculate its limits.
+	analysis *ast.Schema
+}
+
+// Request of a GraphQL operation.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Execute GraphQL request on behalf of the user.
+// Operation that is invalid or exceeds the limits is rejected without resolving any of its fields.
+func (s *Schema) Execute(ctx context.Context, userID int, request Request) *graphqlgo.Response {
+	if errs := s.engine.ValidateWithVariables(request.Query, request.Variables); len(errs) > 0 {
+		return &graphqlgo.Response{Errors: errs}
+	}
+
+	if err := s.limit(request); err != nil {
+		return &graphqlgo.Response{Errors: []*errors.QueryError{err}}
+	}
+
+	return s.engine.Exec(context.WithValue(ctx, userIDKey{}, userID), request.Query, request.OperationName, request.Variables)
+}
+
+type userIDKey struct{}
+
+// userID the operation is executed on behalf of.
+func userID(ctx context.Context) int {
+	id, _ := ctx.Value(userIDKey{}).(int)
+	return id
+}
+
+// resolveError exposes problem type and status of the error in extensions of the response.
+type resolveError struct {
+	message    string
+	extensions map[string]interface{}
+}
+
+func (e resolveError) Error() string {
+	return e.message
+}
+
+// Extensions of the error, used by graphql-go when the error is responded.
+func (e resolveError) Extensions() map[string]interface{} {
+	return e.extensions
+}
+
+// fail converts error of a resolver, only message of known errors is exposed.
+func fail(err error) error {
+	typ, detail, ok := problem.Lookup(err)
+	if !ok {
+		logger.Error("resolve error", zap.Error(err))
+		typ, detail = problem.TypeInternal, problem.TypeInternal.Title
+	}
+
+	return resolveError{
+		message:    detail,
+		extensions: map[string]interface{}{"type": typ.URI, "status": typ.Status},
+	}
+}
@@ -0,0 +1,269 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/api/graphql"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema_Execute(t *testing.T) {
+	var (
+		createdAt = time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+		dueAt     = time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+		trueb     = true
+	)
+
+	tests := []struct {
+		name       string
+		request    graphql.Request
+		response   string
+		mockRepo   func(repo *reltest.Repository)
+		mockTodos  func(todos *todostest.Service)
+		mockScores func(scores *scorestest.Service)
+	}{
+		{
+			name:     "todos",
+			request:  graphql.Request{Query: `{ todos(keyword: "Wake", completed: true) { id title completed dueAt priority tags url createdAt } }`},
+			response: `{"data":{"todos":[{"id":1,"title":"Wake","completed":true,"dueAt":"2026-10-20T00:00:00Z","priority":"high","tags":["home"],"url":"v2/todos/1","createdAt":"2026-10-19T08:00:00Z"}]}}`,
+			mockTodos: todostest.MockSearch(
+				[]todos.Todo{{ID: 1, UserID: 1, Title: "Wake", Completed: true, DueAt: &dueAt, Priority: todos.PriorityHigh, Tags: todos.Tags{"home"}, CreatedAt: createdAt}},
+				todos.Filter{UserID: 1, Keyword: "Wake", Completed: &trueb},
+				nil,
+			),
+		},
+		{
+			name:     "points of todos are loaded in a single query",
+			request:  graphql.Request{Query: `{ todos { id points { name count } } }`},
+			response: `{"data":{"todos":[{"id":1,"points":[{"name":"todo completed","count":10}]},{"id":2,"points":[]}]}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFindAll(where.Eq("source_type", todos.SourceTodo).And(where.InInt("source_id", []int{1, 2})), rel.SortDesc("id")).
+					Result([]scores.Point{{ID: 1, Name: "todo completed", Count: 10, SourceType: todos.SourceTodo, SourceID: 1}})
+			},
+			mockTodos: todostest.MockSearch([]todos.Todo{{ID: 1, UserID: 1}, {ID: 2, UserID: 1}}, todos.Filter{UserID: 1}, nil),
+		},
+		{
+			name:     "nested points and todos are loaded in a single query per level",
+			request:  graphql.Request{Query: `{ todo(id: 1) { points { id todo { title points { id } } } } }`},
+			response: `{"data":{"todo":{"points":[{"id":1,"todo":{"title":"Sleep","points":[{"id":1}]}}]}}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+				repo.ExpectFindAll(where.Eq("source_type", todos.SourceTodo).And(where.InInt("source_id", []int{1})), rel.SortDesc("id")).
+					Result([]scores.Point{{ID: 1, Name: "todo completed", Count: 10, SourceType: todos.SourceTodo, SourceID: 1}})
+				repo.ExpectFindAll(where.InInt("id", []int{1}).AndEq("user_id", 1)).Result([]todos.Todo{{ID: 1, UserID: 1, Title: "Sleep"}})
+				repo.ExpectFindAll(where.Eq("source_type", todos.SourceTodo).And(where.InInt("source_id", []int{1})), rel.SortDesc("id")).
+					Result([]scores.Point{{ID: 1, Name: "todo completed", Count: 10, SourceType: todos.SourceTodo, SourceID: 1}})
+			},
+		},
+		{
+			name:     "todo",
+			request:  graphql.Request{Query: `query Todo($id: Int!) { todo(id: $id) { id title } }`, Variables: map[string]interface{}{"id": float64(1)}},
+			response: `{"data":{"todo":{"id":1,"title":"Sleep"}}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+		},
+		{
+			name:     "todo not found",
+			request:  graphql.Request{Query: `{ todo(id: 2) { id } }`},
+			response: `{"data":{"todo":null}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 2).AndEq("user_id", 1)).NotFound()
+			},
+		},
+		{
+			name:     "score with points and their todo",
+			request:  graphql.Request{Query: `{ score { totalPoint level points(limit: 2) { id todo { title } } } }`},
+			response: `{"data":{"score":{"totalPoint":15,"level":1,"points":[{"id":2,"todo":null},{"id":1,"todo":{"title":"Sleep"}}]}}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFindAll(where.InInt("id", []int{1}).AndEq("user_id", 1)).Result([]todos.Todo{{ID: 1, UserID: 1, Title: "Sleep"}})
+			},
+			mockScores: func(service *scorestest.Service) {
+				scorestest.MockFind(scores.Score{ID: 1, UserID: 1, TotalPoint: 15, Level: 1}, 1, nil)(service)
+				scorestest.MockPoints(scores.PointPage{Points: []scores.Point{
+					{ID: 2, Name: "bonus", Count: 5, SourceType: scores.SourceAdjustment, SourceID: 1},
+					{ID: 1, Name: "todo completed", Count: 10, SourceType: todos.SourceTodo, SourceID: 1},
+				}}, scores.PointFilter{UserID: 1, Limit: 2}, nil)(service)
+			},
+		},
+		{
+			name:       "points error",
+			request:    graphql.Request{Query: `{ points(limit: 1) { id } }`},
+			response:   `{"data":null,"errors":[{"message":"From must be before to","path":["points"],"extensions":{"type":"/problems/bad-request","status":400}}]}`,
+			mockScores: scorestest.MockPoints(scores.PointPage{}, scores.PointFilter{UserID: 1, Limit: 1}, scores.ErrPointRangeInvalid),
+		},
+		{
+			name:      "create todo",
+			request:   graphql.Request{Query: `mutation ($input: TodoInput!) { createTodo(input: $input) { id title priority } }`, Variables: map[string]interface{}{"input": map[string]interface{}{"title": "Sleep", "priority": "low"}}},
+			response:  `{"data":{"createTodo":{"id":1,"title":"Sleep","priority":"low"}}}`,
+			mockTodos: todostest.MockCreate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Priority: todos.PriorityLow}, nil),
+		},
+		{
+			name:      "create todo validation error",
+			request:   graphql.Request{Query: `mutation { createTodo(input: {notes: "zzz"}) { id } }`},
+			response:  `{"data":null,"errors":[{"message":"Title can't be blank","path":["createTodo"],"extensions":{"type":"/problems/validation","status":422}}]}`,
+			mockTodos: todostest.MockCreate(todos.Todo{}, todos.ErrTodoTitleBlank),
+		},
+		{
+			name:     "create todo with invalid due at",
+			request:  graphql.Request{Query: `mutation { createTodo(input: {title: "Sleep", dueAt: "tomorrow"}) { id } }`},
+			response: `{"data":null,"errors":[{"message":"Due at must be RFC 3339 date time","path":["createTodo"],"extensions":{"type":"/problems/validation","status":422}}]}`,
+		},
+		{
+			name:     "update todo",
+			request:  graphql.Request{Query: `mutation { updateTodo(id: 1, input: {completed: true}) { id completed } }`},
+			response: `{"data":{"updateTodo":{"id":1,"completed":true}}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+			mockTodos: todostest.MockUpdate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Completed: true}, nil),
+		},
+		{
+			name:     "update todo not found",
+			request:  graphql.Request{Query: `mutation { updateTodo(id: 2, input: {completed: true}) { id } }`},
+			response: `{"data":null,"errors":[{"message":"entity not found","path":["updateTodo"],"extensions":{"type":"/problems/not-found","status":404}}]}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 2).AndEq("user_id", 1)).NotFound()
+			},
+		},
+		{
+			name:     "delete todo",
+			request:  graphql.Request{Query: `mutation { deleteTodo(id: 1) }`},
+			response: `{"data":{"deleteTodo":true}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+			mockTodos: todostest.MockDelete(),
+		},
+		{
+			name: "aliases, fragments and directives",
+			request: graphql.Request{
+				Query:         `query Other { score { id } } query Mine($all: Boolean = false) { mine: score { ...ScoreFields ... on Score { level @skip(if: true) } points @include(if: $all) { id } __typename } } fragment ScoreFields on Score { totalPoint }`,
+				OperationName: "Mine",
+			},
+			response:   `{"data":{"mine":{"totalPoint":15,"__typename":"Score"}}}`,
+			mockScores: scorestest.MockFind(scores.Score{ID: 1, UserID: 1, TotalPoint: 15, Level: 1}, 1, nil),
+		},
+		{
+			name:     "syntax error",
+			request:  graphql.Request{Query: `{ todos { id }`},
+			response: `{"errors":[{"message":"syntax error: unexpected \"\", expecting Ident","locations":[{"line":1,"column":15}]}]}`,
+		},
+		{
+			name:     "unknown field",
+			request:  graphql.Request{Query: "{\n  todos { id secret }\n}"},
+			response: `{"errors":[{"message":"Cannot query field \"secret\" on type \"Todo\".","locations":[{"line":2,"column":14}]}]}`,
+		},
+		{
+			name:     "missing argument",
+			request:  graphql.Request{Query: `{ todo { id } }`},
+			response: `{"errors":[{"message":"Field \"todo\" argument \"id\" of type \"Int!\" is required, but it was not provided.","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:     "missing variable",
+			request:  graphql.Request{Query: `query ($id: Int!) { todo(id: $id) { id } }`},
+			response: `{"errors":[{"message":"Variable \"id\" has invalid value null.\nExpected type \"Int!\", found null.","locations":[{"line":1,"column":8}]}]}`,
+		},
+		{
+			name:     "scalar without selection",
+			request:  graphql.Request{Query: `{ score }`},
+			response: `{"errors":[{"message":"Field \"score\" of type \"Score!\" must have a selection of subfields. Did you mean \"score { ... }\"?","locations":[{"line":1,"column":3}]}]}`,
+		},
+		{
+			name:     "query too deep",
+			request:  graphql.Request{Query: `{ todos { points { todo { points { todo { points { id } } } } } } }`},
+			response: `{"errors":[{"message":"Field \"id\" has depth 7 that exceeds max depth 6","locations":[{"line":1,"column":52}]}]}`,
+		},
+		{
+			name:     "query too complex",
+			request:  graphql.Request{Query: `{ todos { id points { id todo { id points { id } } } } }`},
+			response: `{"errors":[{"message":"Query complexity exceeds maximum complexity of 500","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name:     "introspection",
+			request:  graphql.Request{Query: `{ __type(name: "Point") { fields { name type { kind ofType { name } } } } }`},
+			response: `{"data":{"__type":{"fields":[{"name":"id","type":{"kind":"NON_NULL","ofType":{"name":"Int"}}},{"name":"name","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},{"name":"count","type":{"kind":"NON_NULL","ofType":{"name":"Int"}}},{"name":"sourceType","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},{"name":"sourceId","type":{"kind":"NON_NULL","ofType":{"name":"Int"}}},{"name":"createdAt","type":{"kind":"NON_NULL","ofType":{"name":"String"}}},{"name":"todo","type":{"kind":"OBJECT","ofType":null}}]}}}`,
+		},
+		{
+			name:     "query with fragment chain too complex",
+			request:  graphql.Request{Query: fragmentChain(30)},
+			response: `{"errors":[{"message":"Query complexity exceeds maximum complexity of 500","locations":[{"line":1,"column":1}]}]}`,
+		},
+		{
+			name:     "unknown operation",
+			request:  graphql.Request{Query: `query Mine { score { id } }`, OperationName: "Other"},
+			response: `{"errors":[{"message":"Unknown operation named \"Other\""}]}`,
+		},
+		{
+			name:     "introspection is not counted in complexity",
+			request:  graphql.Request{Query: `{ __schema { types { fields { args { type { name } } } } } }`},
+			response: "",
+		},
+		{
+			name:     "introspection too deep",
+			request:  graphql.Request{Query: `{ __schema { types { fields { type { ofType { ofType { ofType { name } } } } } } } }`},
+			response: `{"errors":[{"message":"Field \"ofType\" has depth 7 that exceeds max depth 6","locations":[{"line":1,"column":56}]}]}`,
+		},
+		{
+			name:     "unsupported directive",
+			request:  graphql.Request{Query: `{ score @cached { id } }`},
+			response: `{"errors":[{"message":"Unknown directive \"@cached\".","locations":[{"line":1,"column":9}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				scores     = &scorestest.Service{}
+				schema     = graphql.New(repository, todos, scores)
+			)
+
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			todostest.Mock(todos, test.mockTodos)
+			scorestest.Mock(scores, test.mockScores)
+
+			response := schema.Execute(context.TODO(), 1, test.request)
+			if test.response == "" {
+				assert.Empty(t, response.Errors)
+			} else {
+				encoded, err := json.Marshal(response)
+				assert.Nil(t, err)
+				assert.JSONEq(t, test.response, string(encoded))
+			}
+
+			repository.AssertExpectations(t)
+			todos.AssertExpectations(t)
+			scores.AssertExpectations(t)
+		})
+	}
+}
+
+// fragmentChain of n fragments where every fragment spreads the next one twice, which doubles the complexity of each link.
+func fragmentChain(n int) string {
+	var query strings.Builder
+	query.WriteString("{ ...F0 }")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&query, " fragment F%d on Query { ...F%d ... on Query { ...F%d } }", i, i+1, i+1)
+	}
+
+	fmt.Fprintf(&query, " fragment F%d on Query { score { id } }", n)
+
+	return query.String()
+}
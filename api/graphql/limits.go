@@ -0,0 +1,118 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// limit the complexity of the requested operation, depth is limited by the engine when it's validated.
+// Operation that can't be analyzed is rejected rather than executed without a limit.
+// Introspection fields aren't counted since they're bounded by the schema rather than the data,
+// and fields skipped by @skip or @include are still counted.
+func (s *Schema) limit(request Request) *errors.QueryError {
+	doc, errs := gqlparser.LoadQuery(s.analysis, request.Query)
+	if len(errs) > 0 {
+		return &errors.QueryError{Message: fmt.Sprintf("Query complexity can't be calculated: %s", errs[0].Message)}
+	}
+
+	op := doc.Operations.ForName(request.OperationName)
+	if op == nil {
+		return &errors.QueryError{Message: fmt.Sprintf("Unknown operation named %q", request.OperationName)}
+	}
+
+	l := limiter{max: s.MaxComplexity, variables: request.Variables, fragments: map[string]int{}}
+	if l.selectionSet(op.SelectionSet) > s.MaxComplexity {
+		return &errors.QueryError{
+			Message:   fmt.Sprintf("Query complexity exceeds maximum complexity of %d", s.MaxComplexity),
+			Locations: []errors.Location{{Line: op.Position.Line, Column: op.Position.Column}},
+		}
+	}
+
+	return nil
+}
+
+type limiter struct {
+	max       int
+	variables map[string]interface{}
+	// fragments complexity by name, so a fragment spread many times is only walked once.
+	fragments map[string]int
+}
+
+// selectionSet returns complexity of the selections.
+// The walk stops once complexity exceeds the maximum, so the returned complexity is at most max + 1.
+func (l *limiter) selectionSet(selections ast.SelectionSet) int {
+	complexity := 0
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			complexity += l.field(selection)
+		case *ast.InlineFragment:
+			complexity += l.selectionSet(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			complexity += l.fragment(selection.Definition)
+		}
+
+		if complexity > l.max {
+			return l.max + 1
+		}
+	}
+
+	return complexity
+}
+
+// fragment returns complexity of the fragment, which doesn't depend on where it's spread.
+func (l *limiter) fragment(fragment *ast.FragmentDefinition) int {
+	if complexity, ok := l.fragments[fragment.Name]; ok {
+		return complexity
+	}
+
+	complexity := l.selectionSet(fragment.SelectionSet)
+	l.fragments[fragment.Name] = complexity
+
+	return complexity
+}
+
+// field returns complexity of the field.
+// List field multiplies the complexity of its selections by its limit argument or the default list size.
+func (l *limiter) field(field *ast.Field) int {
+	if strings.HasPrefix(field.Name, "__") {
+		return 0
+	}
+
+	if len(field.SelectionSet) == 0 {
+		return 1
+	}
+
+	size := 1
+	if field.Definition.Type.Elem != nil {
+		size = DefaultListSize
+		if limit := listLimit(field.ArgumentMap(l.variables)["limit"]); limit > 0 {
+			size = limit
+		}
+	}
+
+	complexity := l.selectionSet(field.SelectionSet)
+	if complexity > l.max/size {
+		return l.max + 1
+	}
+
+	return 1 + size*complexity
+}
+
+// listLimit of limit argument, which is int64 when it's a literal or float64 when it's a json variable.
+func listLimit(value interface{}) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	}
+
+	return 0
+}
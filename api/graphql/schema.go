@@ -0,0 +1,39 @@
+package graphql
+
+import (
+	_ "embed"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed schema.graphql
+var sdl string
+
+// resolver of the query and mutation types, every field is resolved on behalf of the user in the context.
+type resolver struct {
+	repository rel.Repository
+	todos      todos.Service
+	scores     scores.Service
+}
+
+// New schema of todos and scores, the schema is documented in schema.graphql.
+func New(repository rel.Repository, todos todos.Service, scores scores.Service) *Schema {
+	r := resolver{repository: repository, todos: todos, scores: scores}
+
+	return &Schema{
+		MaxComplexity: DefaultMaxComplexity,
+		engine:        graphqlgo.MustParseSchema(sdl, &r, graphqlgo.MaxDepth(DefaultMaxDepth)),
+		analysis:      gqlparser.MustLoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl}),
+	}
+}
+
+// timestamp formats time as RFC 3339 string.
+func timestamp(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
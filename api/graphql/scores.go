@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel/where"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// pointArgs filters points of the user, same as GET /score/points.
+type pointArgs struct {
+	Name       *string
+	SourceType *string
+	SourceID   *int32
+	Cursor     *int32
+	Limit      *int32
+}
+
+// scoreResolver resolves fields of the user's score.
+type scoreResolver struct {
+	// resolver resolves points of the score the same as points of the query.
+	resolver
+	score scores.Score
+}
+
+func (s *scoreResolver) ID() int32            { return int32(s.score.ID) }
+func (s *scoreResolver) TotalPoint() int32    { return int32(s.score.TotalPoint) }
+func (s *scoreResolver) CurrentStreak() int32 { return int32(s.score.CurrentStreak) }
+func (s *scoreResolver) LongestStreak() int32 { return int32(s.score.LongestStreak) }
+func (s *scoreResolver) StreakOn() string     { return s.score.StreakOn }
+func (s *scoreResolver) Level() int32         { return int32(s.score.Level) }
+func (s *scoreResolver) LevelProgress() int32 { return int32(s.score.LevelProgress) }
+func (s *scoreResolver) NextLevelAt() int32   { return int32(s.score.NextLevelAt) }
+
+// pointResolver resolves fields of a point, its todo is loaded beforehand together with todos of other points.
+type pointResolver struct {
+	point scores.Point
+	todo  *todoResolver
+}
+
+func (p *pointResolver) ID() int32           { return int32(p.point.ID) }
+func (p *pointResolver) Name() string        { return p.point.Name }
+func (p *pointResolver) Count() int32        { return int32(p.point.Count) }
+func (p *pointResolver) SourceType() string  { return p.point.SourceType }
+func (p *pointResolver) SourceID() int32     { return int32(p.point.SourceID) }
+func (p *pointResolver) CreatedAt() string   { return timestamp(p.point.CreatedAt) }
+func (p *pointResolver) Todo() *todoResolver { return p.todo }
+
+// Score of the user.
+func (r resolver) Score(ctx context.Context) (*scoreResolver, error) {
+	var score scores.Score
+	if err := r.scores.Find(ctx, &score, userID(ctx)); err != nil {
+		return nil, fail(err)
+	}
+
+	return &scoreResolver{resolver: r, score: score}, nil
+}
+
+// Points of the user, the score a field belongs to is always the user's own.
+func (r resolver) Points(ctx context.Context, args pointArgs) ([]*pointResolver, error) {
+	filter := scores.PointFilter{
+		UserID:     userID(ctx),
+		Name:       value(args.Name),
+		SourceType: value(args.SourceType),
+		SourceID:   int(value(args.SourceID)),
+		Cursor:     int(value(args.Cursor)),
+		Limit:      int(value(args.Limit)),
+	}
+
+	page, err := r.scores.Points(ctx, filter)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return r.pointResolvers(ctx, page.Points, "")
+}
+
+// pointResolvers of the list, todo of every point earned from a todo is loaded with a single query when it's selected.
+// Other points have no todo.
+func (r resolver) pointResolvers(ctx context.Context, list []scores.Point, path string) ([]*pointResolver, error) {
+	var (
+		ids    []int
+		result = make([]*pointResolver, len(list))
+	)
+
+	for i := range list {
+		result[i] = &pointResolver{point: list[i]}
+		if list[i].SourceType == todos.SourceTodo {
+			ids = append(ids, list[i].SourceID)
+		}
+	}
+
+	if len(ids) == 0 || !graphqlgo.HasSelectedField(ctx, path+"todo") {
+		return result, nil
+	}
+
+	var found []todos.Todo
+	if err := r.repository.FindAll(ctx, &found, where.InInt("id", ids).AndEq("user_id", userID(ctx))); err != nil {
+		return nil, fail(err)
+	}
+
+	resolvers, err := r.todoResolvers(ctx, found, path+"todo.")
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*todoResolver, len(resolvers))
+	for i := range resolvers {
+		byID[int(found[i].ID)] = resolvers[i]
+	}
+
+	for i := range result {
+		if list[i].SourceType == todos.SourceTodo {
+			result[i].todo = byID[list[i].SourceID]
+		}
+	}
+
+	return result, nil
+}
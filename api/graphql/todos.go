@@ -0,0 +1,276 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+var (
+	// ErrTodoDueAtInvalid validation error.
+	ErrTodoDueAtInvalid = problem.Error{Type: problem.TypeValidation, Err: errors.New("Due at must be RFC 3339 date time")}
+)
+
+// todoResolver resolves fields of a todo, its points are loaded beforehand together with points of other todos.
+type todoResolver struct {
+	todo   todos.Todo
+	points []*pointResolver
+}
+
+func (t *todoResolver) ID() int32         { return int32(t.todo.ID) }
+func (t *todoResolver) Title() string     { return t.todo.Title }
+func (t *todoResolver) Notes() string     { return t.todo.Notes }
+func (t *todoResolver) Completed() bool   { return t.todo.Completed }
+func (t *todoResolver) Order() int32      { return int32(t.todo.Order) }
+func (t *todoResolver) Priority() string  { return t.todo.Priority.String() }
+func (t *todoResolver) List() string      { return t.todo.List }
+func (t *todoResolver) CreatedAt() string { return timestamp(t.todo.CreatedAt) }
+func (t *todoResolver) UpdatedAt() string { return timestamp(t.todo.UpdatedAt) }
+
+func (t *todoResolver) DueAt() *string {
+	if t.todo.DueAt == nil {
+		return nil
+	}
+
+	dueAt := timestamp(*t.todo.DueAt)
+	return &dueAt
+}
+
+func (t *todoResolver) Tags() []string {
+	return append([]string{}, t.todo.Tags...)
+}
+
+func (t *todoResolver) URL() string {
+	return fmt.Sprint(todos.TodoURLPrefix(middleware.VersionV2), t.todo.ID)
+}
+
+func (t *todoResolver) Points() []*pointResolver {
+	if t.points == nil {
+		return []*pointResolver{}
+	}
+
+	return t.points
+}
+
+// todoInput sets only the fields that are present in the input, null clears the field.
+// Tags can't be cleared by null since absent and null list are the same to graphql-go, an empty list clears them.
+type todoInput struct {
+	Title     graphqlgo.NullString
+	Notes     graphqlgo.NullString
+	Completed graphqlgo.NullBool
+	Order     graphqlgo.NullInt
+	DueAt     graphqlgo.NullString
+	Priority  graphqlgo.NullString
+	List      graphqlgo.NullString
+	Tags      *[]string
+}
+
+// apply input to the todo.
+func (i todoInput) apply(todo *todos.Todo) error {
+	if i.Title.Set {
+		todo.Title = value(i.Title.Value)
+	}
+
+	if i.Notes.Set {
+		todo.Notes = value(i.Notes.Value)
+	}
+
+	if i.Completed.Set {
+		todo.Completed = value(i.Completed.Value)
+	}
+
+	if i.Order.Set {
+		todo.Order = int(value(i.Order.Value))
+	}
+
+	if i.List.Set {
+		todo.List = value(i.List.Value)
+	}
+
+	if i.DueAt.Set {
+		todo.DueAt = nil
+		if i.DueAt.Value != nil {
+			dueAt, err := time.Parse(time.RFC3339, *i.DueAt.Value)
+			if err != nil {
+				return ErrTodoDueAtInvalid
+			}
+
+			todo.DueAt = &dueAt
+		}
+	}
+
+	if i.Priority.Set {
+		todo.Priority = todos.PriorityNone
+		if i.Priority.Value != nil {
+			if err := todo.Priority.UnmarshalJSON([]byte(strconv.Quote(*i.Priority.Value))); err != nil {
+				return err
+			}
+		}
+	}
+
+	if i.Tags != nil {
+		todo.Tags = nil
+		if len(*i.Tags) > 0 {
+			todo.Tags = append(todos.Tags{}, *i.Tags...)
+		}
+	}
+
+	return nil
+}
+
+// value of nullable input, zero value when it's null.
+func value[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
+// Todos of the user, filtered by keyword and completion.
+func (r resolver) Todos(ctx context.Context, args struct {
+	Keyword   *string
+	Completed *bool
+}) ([]*todoResolver, error) {
+	var (
+		result []todos.Todo
+		filter = todos.Filter{UserID: userID(ctx), Keyword: value(args.Keyword), Completed: args.Completed}
+	)
+
+	if err := r.todos.Search(ctx, &result, filter); err != nil {
+		return nil, fail(err)
+	}
+
+	return r.todoResolvers(ctx, result, "")
+}
+
+// Todo of the user, missing todo resolves to null.
+func (r resolver) Todo(ctx context.Context, args struct{ ID int32 }) (*todoResolver, error) {
+	todo, err := r.loadTodo(ctx, args.ID)
+	if errors.Is(err, rel.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return r.todoResolver(ctx, todo)
+}
+
+// CreateTodo of the user.
+func (r resolver) CreateTodo(ctx context.Context, args struct{ Input todoInput }) (*todoResolver, error) {
+	var (
+		todo = todos.Todo{}
+	)
+
+	if err := args.Input.apply(&todo); err != nil {
+		return nil, fail(err)
+	}
+
+	todo.UserID = userID(ctx)
+	if err := r.todos.Create(ctx, &todo); err != nil {
+		return nil, fail(err)
+	}
+
+	return r.todoResolver(ctx, todo)
+}
+
+// UpdateTodo of the user with fields present in the input.
+func (r resolver) UpdateTodo(ctx context.Context, args struct {
+	ID    int32
+	Input todoInput
+}) (*todoResolver, error) {
+	todo, err := r.loadTodo(ctx, args.ID)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	changes := rel.NewChangeset(&todo)
+	if err := args.Input.apply(&todo); err != nil {
+		return nil, fail(err)
+	}
+
+	if err := r.todos.Update(ctx, &todo, changes); err != nil {
+		return nil, fail(err)
+	}
+
+	return r.todoResolver(ctx, todo)
+}
+
+// DeleteTodo of the user.
+func (r resolver) DeleteTodo(ctx context.Context, args struct{ ID int32 }) (bool, error) {
+	todo, err := r.loadTodo(ctx, args.ID)
+	if err != nil {
+		return false, fail(err)
+	}
+
+	r.todos.Delete(ctx, &todo)
+	return true, nil
+}
+
+// loadTodo by id, owned by the user.
+func (r resolver) loadTodo(ctx context.Context, id int32) (todos.Todo, error) {
+	var todo todos.Todo
+	err := r.repository.Find(ctx, &todo, where.Eq("id", int(id)).AndEq("user_id", userID(ctx)))
+	return todo, err
+}
+
+func (r resolver) todoResolver(ctx context.Context, todo todos.Todo) (*todoResolver, error) {
+	result, err := r.todoResolvers(ctx, []todos.Todo{todo}, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return result[0], nil
+}
+
+// todoResolvers of the list, points of every todo are loaded with a single query when they're selected.
+// Path is the selection of the todos relative to the field being resolved, eg. "points.todo." for todo of points.
+func (r resolver) todoResolvers(ctx context.Context, list []todos.Todo, path string) ([]*todoResolver, error) {
+	var (
+		ids    = make([]int, len(list))
+		result = make([]*todoResolver, len(list))
+		points []scores.Point
+	)
+
+	for i := range list {
+		ids[i] = int(list[i].ID)
+		result[i] = &todoResolver{todo: list[i]}
+	}
+
+	if len(list) == 0 || !graphqlgo.HasSelectedField(ctx, path+"points") {
+		return result, nil
+	}
+
+	if err := r.repository.FindAll(ctx, &points, where.Eq("source_type", todos.SourceTodo).And(where.InInt("source_id", ids)), rel.SortDesc("id")); err != nil {
+		return nil, fail(err)
+	}
+
+	resolvers, err := r.pointResolvers(ctx, points, path+"points.")
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[int][]*pointResolver)
+	for i := range resolvers {
+		grouped[points[i].SourceID] = append(grouped[points[i].SourceID], resolvers[i])
+	}
+
+	for i := range result {
+		result[i].points = grouped[ids[i]]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/achievements"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/scores"
+)
+
+// Achievements for achievement endpoints.
+type Achievements struct {
+	scores       scores.Service
+	achievements achievements.Service
+}
+
+// Index handle GET /
+func (a Achievements) Index(c *gin.Context) {
+	var (
+		score scores.Score
+	)
+
+	if err := a.scores.Find(c, &score, middleware.UserID(c)); err != nil {
+		panic(err)
+	}
+
+	result, err := a.achievements.List(c, score)
+	if err != nil {
+		panic(err)
+	}
+
+	render(c, result, 200)
+}
+
+// Mount handlers to router group.
+func (a Achievements) Mount(router *gin.RouterGroup) {
+	router.GET("/", a.Index)
+}
+
+// NewAchievements handler.
+func NewAchievements(scores scores.Service, achievements achievements.Service) Achievements {
+	return Achievements{
+		scores:       scores,
+		achievements: achievements,
+	}
+}
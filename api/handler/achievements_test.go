@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/achievements"
+	"github.com/go-rel/gin-example/achievements/achievementstest"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAchievements_Index(t *testing.T) {
+	var (
+		unlockedAt = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+		score      = scores.Score{ID: 1, UserID: 1, TotalPoint: 150, LongestStreak: 3}
+	)
+
+	tests := []struct {
+		name                 string
+		status               int
+		path                 string
+		response             string
+		mockScoresFind       func(scores *scorestest.Service)
+		mockAchievementsList func(achievements *achievementstest.Service)
+	}{
+		{
+			name:           "ok",
+			status:         http.StatusOK,
+			path:           "/",
+			response:       `[{"code":"100_points", "name":"100 Points", "description":"Earn 100 points.", "metric":"total_point", "goal":100, "progress":100, "unlocked":true, "unlocked_at":"2026-10-14T15:04:00Z"}, {"code":"7_day_streak", "name":"7-Day Streak", "description":"Complete a todo 7 days in a row.", "metric":"longest_streak", "goal":7, "progress":3, "unlocked":false, "unlocked_at":null}]`,
+			mockScoresFind: scorestest.MockFind(score, 1, nil),
+			mockAchievementsList: achievementstest.MockList([]achievements.Progress{
+				{Achievement: achievements.Definitions[1], Progress: 100, Unlocked: true, UnlockedAt: &unlockedAt},
+				{Achievement: achievements.Definitions[2], Progress: 3},
+			}, score, nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router       = gin.New()
+				scores       = &scorestest.Service{}
+				achievements = &achievementstest.Service{}
+				handler      = handler.NewAchievements(scores, achievements)
+				req, _       = http.NewRequest("GET", test.path, nil)
+				rr           = httptest.NewRecorder()
+			)
+
+			scorestest.Mock(scores, test.mockScoresFind)
+			achievementstest.Mock(achievements, test.mockAchievementsList)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			scores.AssertExpectations(t)
+			achievements.AssertExpectations(t)
+		})
+	}
+}
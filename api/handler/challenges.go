@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/challenges"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+const (
+	challengeLoadKey string = "challengesLoadKey"
+)
+
+// Challenges for challenges endpoints.
+type Challenges struct {
+	repository rel.Repository
+	challenges challenges.Service
+}
+
+// Index handle GET /.
+func (ch Challenges) Index(c *gin.Context) {
+	result, err := ch.challenges.List(c, middleware.UserID(c))
+	if err != nil {
+		panic(err)
+	}
+
+	render(c, result, 200)
+}
+
+// Enrol handle POST /{ID}/enrol
+func (ch Challenges) Enrol(c *gin.Context) {
+	var (
+		challenge = c.MustGet(challengeLoadKey).(challenges.Challenge)
+		enrolment challenges.Enrolment
+	)
+
+	if err := ch.challenges.Enrol(c, &enrolment, challenge, middleware.UserID(c)); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	render(c, enrolment, 201)
+}
+
+// Load is middleware that loads challenge to context.
+func (ch Challenges) Load(c *gin.Context) {
+	var (
+		id, _     = strconv.Atoi(c.Param("ID"))
+		challenge challenges.Challenge
+	)
+
+	if err := ch.repository.Find(c, &challenge, where.Eq("id", id)); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Set(challengeLoadKey, challenge)
+	c.Next()
+}
+
+// Mount handlers to router group.
+func (ch Challenges) Mount(router *gin.RouterGroup) {
+	router.GET("/", ch.Index)
+	router.POST("/:ID/enrol", ch.Load, ch.Enrol)
+}
+
+// NewChallenges handler.
+func NewChallenges(repository rel.Repository, challenges challenges.Service) Challenges {
+	return Challenges{
+		repository: repository,
+		challenges: challenges,
+	}
+}
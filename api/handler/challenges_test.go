@@ -0,0 +1,144 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/challenges"
+	"github.com/go-rel/gin-example/challenges/challengestest"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallenges_Index(t *testing.T) {
+	var (
+		router        = gin.New()
+		req, _        = http.NewRequest("GET", "/", nil)
+		rr            = httptest.NewRecorder()
+		repository    = reltest.New()
+		challengesSvc = &challengestest.Service{}
+		handler       = handler.NewChallenges(repository, challengesSvc)
+		startAt       = time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+		endAt         = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	)
+
+	challengestest.Mock(challengesSvc, challengestest.MockList([]challenges.Progress{
+		{Challenge: challenges.Challenge{ID: 1, Name: "Weekly 20", Metric: challenges.MetricCompletions, Goal: 20, Bonus: 10, StartAt: startAt, EndAt: endAt}, Enrolled: true, Progress: 12},
+	}, 1, nil))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":1, "name":"Weekly 20", "description":"", "metric":"completions", "goal":20, "bonus":10, "start_at":"2026-10-12T00:00:00Z", "end_at":"2026-10-19T00:00:00Z", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z", "enrolled":true, "progress":12, "completed_at":null}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	challengesSvc.AssertExpectations(t)
+}
+
+func TestChallenges_Enrol(t *testing.T) {
+	var (
+		challenge = challenges.Challenge{ID: 1, Name: "Weekly 20", Metric: challenges.MetricCompletions, Goal: 20}
+	)
+
+	tests := []struct {
+		name           string
+		status         int
+		path           string
+		response       string
+		isPanic        bool
+		mockRepo       func(repo *reltest.Repository)
+		mockChallenges func(challenges *challengestest.Service)
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			path:     "/1/enrol",
+			response: `{"id":2, "user_id":1, "challenge_id":1, "completed_at":null, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(challenge)
+			},
+			mockChallenges: challengestest.MockEnrol(challenges.Enrolment{ID: 2, UserID: 1, ChallengeID: 1}, challenge, 1, nil),
+		},
+		{
+			name:     "ended",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/1/enrol",
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Challenge already ended","instance":"/1/enrol"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(challenge)
+			},
+			mockChallenges: challengestest.MockEnrol(challenges.Enrolment{}, challenge, 1, challenges.ErrChallengeEnded),
+		},
+		{
+			name:     "enrolled",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/1/enrol",
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Already enrolled to the challenge","instance":"/1/enrol"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(challenge)
+			},
+			mockChallenges: challengestest.MockEnrol(challenges.Enrolment{}, challenge, 1, challenges.ErrChallengeEnrolled),
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			path:     "/1/enrol",
+			response: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"entity not found","instance":"/1/enrol"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+			},
+		},
+		{
+			name:    "panic",
+			path:    "/1/enrol",
+			isPanic: true,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(challenge)
+			},
+			mockChallenges: challengestest.MockEnrol(challenges.Enrolment{}, challenge, 1, reltest.ErrConnectionClosed),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router        = gin.New()
+				req, _        = http.NewRequest("POST", test.path, nil)
+				rr            = httptest.NewRecorder()
+				repository    = reltest.New()
+				challengesSvc = &challengestest.Service{}
+				handler       = handler.NewChallenges(repository, challengesSvc)
+			)
+
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			challengestest.Mock(challengesSvc, test.mockChallenges)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+
+			if test.isPanic {
+				assert.Panics(t, func() {
+					router.ServeHTTP(rr, req)
+				})
+			} else {
+				router.ServeHTTP(rr, req)
+				assert.Equal(t, test.status, rr.Code)
+				assert.JSONEq(t, test.response, rr.Body.String())
+			}
+
+			repository.AssertExpectations(t)
+			challengesSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/events"
+)
+
+const (
+	// DefaultHeartbeat is the interval of heartbeats when it's not specified.
+	DefaultHeartbeat = 15 * time.Second
+	// EventReset is sent when events after Last-Event-ID are no longer buffered, client should reload its state.
+	EventReset = "reset"
+)
+
+// Events for server-sent events endpoint.
+type Events struct {
+	buffer    *events.Buffer
+	heartbeat time.Duration
+}
+
+// Stream handle GET /
+// Streams changes of user's todos and score until the client disconnects or the buffer is closed.
+// Buffered events after Last-Event-ID are sent first, so a reconnecting client doesn't miss any change.
+func (e Events) Stream(c *gin.Context) {
+	var (
+		ctx          = c.Request.Context()
+		userID       = middleware.UserID(c)
+		lastID, err  = strconv.ParseInt(c.GetHeader("Last-Event-ID"), 10, 64)
+		resume       = err == nil
+		wait, cancel = e.buffer.Wait()
+		heartbeat    = time.NewTicker(e.heartbeat)
+	)
+
+	defer cancel()
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// disable response buffering of nginx.
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	c.Writer.Flush()
+
+	// fresh connection only receives events published from now on.
+	if !resume {
+		lastID = e.buffer.LastID()
+	}
+
+	for {
+		entries, ok := e.buffer.Since(lastID)
+		if !ok {
+			c.Render(-1, sse.Event{Event: EventReset, Data: ""})
+		}
+
+		for _, entry := range entries {
+			lastID = entry.ID
+			if entry.UserID != userID {
+				continue
+			}
+
+			c.Render(-1, sse.Event{Id: strconv.FormatInt(entry.ID, 10), Event: entry.Name, Data: entry.Event})
+		}
+
+		c.Writer.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case _, open := <-wait:
+			if !open {
+				return
+			}
+		case <-heartbeat.C:
+			// comment line keeps proxies from closing idle connection.
+			c.Writer.WriteString(":heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+// Mount handlers to router group.
+func (e Events) Mount(router *gin.RouterGroup) {
+	router.GET("", e.Stream)
+}
+
+// NewEvents handler sending heartbeat every interval, DefaultHeartbeat is used when it's not positive.
+func NewEvents(buffer *events.Buffer, heartbeat time.Duration) Events {
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	return Events{
+		buffer:    buffer,
+		heartbeat: heartbeat,
+	}
+}
@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents_Stream(t *testing.T) {
+	var (
+		at        = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		published = []events.Event{
+			{Name: "todo.created", UserID: 1, At: at},
+			{Name: "todo.updated", UserID: 1, At: at},
+			{Name: "todo.created", UserID: 2, At: at},
+			{Name: "todos.cleared", UserID: 1, At: at},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		size        int
+		lastEventID string
+		response    string
+	}{
+		{
+			name:     "fresh connection",
+			size:     10,
+			response: "",
+		},
+		{
+			name:        "resume",
+			size:        10,
+			lastEventID: "1",
+			response: "id:2\nevent:todo.updated\ndata:{\"name\":\"todo.updated\",\"user_id\":1,\"data\":null,\"at\":\"2026-10-19T09:00:00Z\"}\n\n" +
+				"id:4\nevent:todos.cleared\ndata:{\"name\":\"todos.cleared\",\"user_id\":1,\"data\":null,\"at\":\"2026-10-19T09:00:00Z\"}\n\n",
+		},
+		{
+			name:        "resume from dropped event",
+			size:        2,
+			lastEventID: "1",
+			response: "event:reset\ndata:\n\n" +
+				"id:4\nevent:todos.cleared\ndata:{\"name\":\"todos.cleared\",\"user_id\":1,\"data\":null,\"at\":\"2026-10-19T09:00:00Z\"}\n\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router  = gin.New()
+				req, _  = http.NewRequest("GET", "/events", nil)
+				rr      = httptest.NewRecorder()
+				buffer  = events.NewBuffer(test.size)
+				handler = handler.NewEvents(buffer, time.Minute)
+			)
+
+			for _, event := range published {
+				buffer.Publish(context.TODO(), event)
+			}
+
+			// closed buffer ends the stream after buffered events are sent.
+			buffer.Close()
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			if test.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", test.lastEventID)
+			}
+
+			handler.Mount(router.Group("/events", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+			assert.Equal(t, test.response, rr.Body.String())
+		})
+	}
+}
+
+func TestEvents_Stream_heartbeat(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		router      = gin.New()
+		req, _      = http.NewRequestWithContext(ctx, "GET", "/events", nil)
+		rr          = httptest.NewRecorder()
+		handler     = handler.NewEvents(events.NewBuffer(10), time.Millisecond)
+	)
+
+	defer cancel()
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/events", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), ":heartbeat\n\n")
+}
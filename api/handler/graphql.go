@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/graphql"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
+	"go.uber.org/zap"
+)
+
+// GraphQL for GraphQL endpoint.
+type GraphQL struct {
+	schema *graphql.Schema
+}
+
+// Query handle POST /graphql
+// Errors of the operation are reported in the response body with status 200, only malformed request is responded as problem.
+// Response is always JSON as required by GraphQL over HTTP, regardless of negotiated format.
+func (g GraphQL) Query(c *gin.Context) {
+	var (
+		request graphql.Request
+	)
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	c.JSON(200, g.schema.Execute(c, middleware.UserID(c), request))
+}
+
+// Mount handlers to router group.
+func (g GraphQL) Mount(router *gin.RouterGroup) {
+	router.POST("", g.Query)
+}
+
+// NewGraphQL handler.
+func NewGraphQL(schema *graphql.Schema) GraphQL {
+	return GraphQL{
+		schema: schema,
+	}
+}
@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/graphql"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL_Query(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		payload     string
+		contentType string
+		response    string
+		mockScores  func(scores *scorestest.Service)
+	}{
+		{
+			name:        "ok",
+			status:      http.StatusOK,
+			payload:     `{"query":"{ score { totalPoint } }"}`,
+			contentType: "application/json; charset=utf-8",
+			response:    `{"data":{"score":{"totalPoint":10}}}`,
+			mockScores:  scorestest.MockFind(scores.Score{ID: 1, UserID: 1, TotalPoint: 10}, 1, nil),
+		},
+		{
+			name:        "operation error",
+			status:      http.StatusOK,
+			payload:     `{"query":"{ score { secret } }"}`,
+			contentType: "application/json; charset=utf-8",
+			response:    `{"errors":[{"message":"Cannot query field \"secret\" on type \"Score\".","locations":[{"line":1,"column":11}]}]}`,
+		},
+		{
+			name:        "bad request",
+			status:      http.StatusBadRequest,
+			payload:     `{"query":`,
+			contentType: "application/problem+json",
+			response:    `{"type":"/problems/bad-request", "title":"Bad Request", "status":400, "detail":"Request body is malformed", "instance":"/graphql"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router  = gin.New()
+				body    = strings.NewReader(test.payload)
+				req, _  = http.NewRequest("POST", "/graphql", body)
+				rr      = httptest.NewRecorder()
+				todos   = &todostest.Service{}
+				scores  = &scorestest.Service{}
+				handler = handler.NewGraphQL(graphql.New(reltest.New(), todos, scores))
+			)
+
+			scorestest.Mock(scores, test.mockScores)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/graphql", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.contentType, rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			scores.AssertExpectations(t)
+		})
+	}
+}
@@ -1,35 +1,42 @@
 package handler
 
 import (
-	"errors"
-
 	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/format"
+	"github.com/go-rel/gin-example/api/problem"
 	"go.uber.org/zap"
 )
 
 var (
 	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "handler")))
-	// ErrBadRequest error.
-	ErrBadRequest = errors.New("Bad Request")
 )
 
 func render(c *gin.Context, body interface{}, status int) {
 	switch v := body.(type) {
 	case string:
-		c.JSON(status, struct {
+		render(c, struct {
 			Message string `json:"message"`
 		}{
 			Message: v,
-		})
+		}, status)
 	case error:
-		c.JSON(status, struct {
-			Error string `json:"error"`
-		}{
-			Error: v.Error(),
-		})
+		// status of an error is decided by its problem type.
+		problem.Render(c, v)
 	case nil:
 		c.Status(status)
 	default:
-		c.JSON(status, body)
+		if err := format.Render(c, status, body); err != nil {
+			renderError(c, err)
+		}
+	}
+}
+
+// renderError renders err as problem details.
+// Error that's not mapped to a problem type is unexpected, it panics so recovery middleware logs it and responds with internal error.
+func renderError(c *gin.Context, err error) {
+	if !problem.Known(err) {
+		panic(err)
 	}
+
+	problem.Render(c, err)
 }
@@ -56,7 +56,7 @@ func TestRender(t *testing.T) {
 		{
 			name:     "error",
 			data:     errors.New("system error"),
-			response: `{"error":"system error"}`,
+			response: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"instance":"/"}`,
 		},
 		{
 			name:     "nil",
@@ -76,7 +76,7 @@ func TestRender(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			var (
 				rr = httptest.NewRecorder()
-				c  = &gin.Context{Writer: &responseRecorder{rr}}
+				c  = &gin.Context{Writer: &responseRecorder{rr}, Request: httptest.NewRequest("GET", "/", nil)}
 			)
 
 			render(c, test.data, 200)
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI for OpenAPI document endpoint.
+type OpenAPI struct {
+	document []byte
+}
+
+// Show handle GET /openapi.json
+func (o OpenAPI) Show(c *gin.Context) {
+	c.Data(200, "application/json", o.document)
+}
+
+// Mount handlers to router group.
+func (o OpenAPI) Mount(router *gin.RouterGroup) {
+	router.GET("/openapi.json", o.Show)
+}
+
+// NewOpenAPI handler.
+func NewOpenAPI(document []byte) OpenAPI {
+	return OpenAPI{
+		document: document,
+	}
+}
@@ -0,0 +1,27 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI_Show(t *testing.T) {
+	var (
+		router  = gin.New()
+		handler = handler.NewOpenAPI([]byte(`{"openapi":"3.0.3"}`))
+		req, _  = http.NewRequest("GET", "/openapi.json", nil)
+		rr      = httptest.NewRecorder()
+	)
+
+	handler.Mount(router.Group("/"))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"openapi":"3.0.3"}`, rr.Body.String())
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/rewards"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+const (
+	rewardLoadKey string = "rewardsLoadKey"
+)
+
+// Rewards for rewards endpoints.
+type Rewards struct {
+	repository rel.Repository
+	rewards    rewards.Service
+}
+
+// Index handle GET /.
+func (r Rewards) Index(c *gin.Context) {
+	var (
+		result []rewards.Reward
+	)
+
+	if err := r.rewards.Catalogue(c, &result); err != nil {
+		panic(err)
+	}
+
+	render(c, result, 200)
+}
+
+// Redeem handle POST /{ID}/redeem
+func (r Rewards) Redeem(c *gin.Context) {
+	var (
+		reward     = c.MustGet(rewardLoadKey).(rewards.Reward)
+		redemption rewards.Redemption
+	)
+
+	if err := r.rewards.Redeem(c, &redemption, reward, middleware.UserID(c)); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	render(c, redemption, 201)
+}
+
+// Redemptions handle GET /redemptions
+func (r Rewards) Redemptions(c *gin.Context) {
+	var (
+		result []rewards.Redemption
+	)
+
+	if err := r.rewards.History(c, &result, middleware.UserID(c)); err != nil {
+		panic(err)
+	}
+
+	render(c, result, 200)
+}
+
+// Load is middleware that loads reward to context.
+func (r Rewards) Load(c *gin.Context) {
+	var (
+		id, _  = strconv.Atoi(c.Param("ID"))
+		reward rewards.Reward
+	)
+
+	if err := r.repository.Find(c, &reward, where.Eq("id", id)); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Set(rewardLoadKey, reward)
+	c.Next()
+}
+
+// Mount handlers to router group.
+func (r Rewards) Mount(router *gin.RouterGroup) {
+	router.GET("/", r.Index)
+	router.GET("/redemptions", r.Redemptions)
+	router.POST("/:ID/redeem", r.Load, r.Redeem)
+}
+
+// NewRewards handler.
+func NewRewards(repository rel.Repository, rewards rewards.Service) Rewards {
+	return Rewards{
+		repository: repository,
+		rewards:    rewards,
+	}
+}
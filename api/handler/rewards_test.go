@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/rewards"
+	"github.com/go-rel/gin-example/rewards/rewardstest"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewards_Index(t *testing.T) {
+	var (
+		router     = gin.New()
+		req, _     = http.NewRequest("GET", "/", nil)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		rewardsSvc = &rewardstest.Service{}
+		handler    = handler.NewRewards(repository, rewardsSvc)
+	)
+
+	rewardstest.Mock(rewardsSvc, rewardstest.MockCatalogue([]rewards.Reward{{ID: 1, Name: "Coffee", Description: "A cup of coffee", Cost: 10}}, nil))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":1, "name":"Coffee", "description":"A cup of coffee", "cost":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	rewardsSvc.AssertExpectations(t)
+}
+
+func TestRewards_Redeem(t *testing.T) {
+	var (
+		reward = rewards.Reward{ID: 1, Name: "Coffee", Cost: 10}
+	)
+
+	tests := []struct {
+		name        string
+		status      int
+		path        string
+		response    string
+		isPanic     bool
+		mockRepo    func(repo *reltest.Repository)
+		mockRewards func(rewards *rewardstest.Service)
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			path:     "/1/redeem",
+			response: `{"id":2, "user_id":1, "reward_id":1, "name":"Coffee", "cost":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(reward)
+			},
+			mockRewards: rewardstest.MockRedeem(rewards.Redemption{ID: 2, UserID: 1, RewardID: 1, Name: "Coffee", Cost: 10}, reward, 1, nil),
+		},
+		{
+			name:     "insufficient points",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/1/redeem",
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Insufficient points","instance":"/1/redeem"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(reward)
+			},
+			mockRewards: rewardstest.MockRedeem(rewards.Redemption{}, reward, 1, scores.ErrPointInsufficient),
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			path:     "/1/redeem",
+			response: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"entity not found","instance":"/1/redeem"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+			},
+		},
+		{
+			name:    "panic",
+			path:    "/1/redeem",
+			isPanic: true,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(reward)
+			},
+			mockRewards: rewardstest.MockRedeem(rewards.Redemption{}, reward, 1, reltest.ErrConnectionClosed),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router     = gin.New()
+				req, _     = http.NewRequest("POST", test.path, nil)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				rewardsSvc = &rewardstest.Service{}
+				handler    = handler.NewRewards(repository, rewardsSvc)
+			)
+
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			rewardstest.Mock(rewardsSvc, test.mockRewards)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+
+			if test.isPanic {
+				assert.Panics(t, func() {
+					router.ServeHTTP(rr, req)
+				})
+			} else {
+				router.ServeHTTP(rr, req)
+				assert.Equal(t, test.status, rr.Code)
+				assert.JSONEq(t, test.response, rr.Body.String())
+			}
+
+			repository.AssertExpectations(t)
+			rewardsSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRewards_Redemptions(t *testing.T) {
+	var (
+		router     = gin.New()
+		req, _     = http.NewRequest("GET", "/redemptions", nil)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		rewardsSvc = &rewardstest.Service{}
+		handler    = handler.NewRewards(repository, rewardsSvc)
+	)
+
+	rewardstest.Mock(rewardsSvc, rewardstest.MockHistory([]rewards.Redemption{{ID: 2, UserID: 1, RewardID: 1, Name: "Coffee", Cost: 10}}, 1, nil))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":2, "user_id":1, "reward_id":1, "name":"Coffee", "cost":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	rewardsSvc.AssertExpectations(t)
+}
@@ -1,45 +1,157 @@
 package handler
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
 	"github.com/go-rel/gin-example/scores"
-	"github.com/go-rel/rel"
+	"go.uber.org/zap"
 )
 
 // Score for score endpoints.
 type Score struct {
-	repository rel.Repository
+	scores scores.Service
 }
 
 // Index handle GET /
 func (s Score) Index(c *gin.Context) {
 	var (
-		result scores.Score
+		result = s.load(c)
 	)
 
-	s.repository.Find(c, &result)
 	render(c, result, 200)
 }
 
 // Points handle Get /points
 func (s Score) Points(c *gin.Context) {
 	var (
-		result []scores.Point
+		request struct {
+			Name       string    `form:"name"`
+			SourceType string    `form:"source_type"`
+			SourceID   int       `form:"source_id"`
+			From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+			To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+			Cursor     int       `form:"cursor"`
+			Limit      int       `form:"limit"`
+			Aggregate  string    `form:"aggregate"`
+		}
+	)
+
+	if err := c.ShouldBindQuery(&request); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	result, err := s.scores.Points(c, scores.PointFilter{
+		UserID:     middleware.UserID(c),
+		Name:       request.Name,
+		SourceType: request.SourceType,
+		SourceID:   request.SourceID,
+		From:       request.From,
+		To:         request.To,
+		Cursor:     request.Cursor,
+		Limit:      request.Limit,
+		Aggregate:  request.Aggregate,
+	})
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	if request.Aggregate != "" {
+		render(c, result.Buckets, 200)
+		return
+	}
+
+	if result.NextCursor != 0 {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("cursor", strconv.Itoa(result.NextCursor))
+		next.RawQuery = query.Encode()
+		c.Writer.Header().Add("Link", "<"+next.RequestURI()+">; rel=\"next\"")
+	}
+
+	render(c, result.Points, 200)
+}
+
+// Adjust handle POST /adjustments
+func (s Score) Adjust(c *gin.Context) {
+	var (
+		request struct {
+			UserID int    `json:"user_id"`
+			Count  int    `json:"count"`
+			Reason string `json:"reason"`
+			Force  bool   `json:"force"`
+		}
+	)
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	adjustment := scores.Adjustment{
+		UserID:     request.UserID,
+		Count:      request.Count,
+		Reason:     request.Reason,
+		AdjustedBy: middleware.UserID(c),
+		Forced:     request.Force,
+	}
+
+	if err := s.scores.Adjust(c, &adjustment); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	logger.Info("score adjusted", zap.Int("adjustment_id", adjustment.ID), zap.Int("user_id", adjustment.UserID), zap.Int("adjusted_by", adjustment.AdjustedBy))
+	render(c, adjustment, 201)
+}
+
+// Leaderboard handle GET /leaderboard
+func (s Score) Leaderboard(c *gin.Context) {
+	var (
+		limit, _ = strconv.Atoi(c.Query("limit"))
+		window   = c.DefaultQuery("window", scores.WindowAll)
 	)
 
-	s.repository.FindAll(c, &result)
+	result, err := s.scores.Leaderboard(c, middleware.UserID(c), window, limit)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
 	render(c, result, 200)
 }
 
+// load score of the caller.
+func (s Score) load(c *gin.Context) scores.Score {
+	var (
+		score scores.Score
+	)
+
+	if err := s.scores.Find(c, &score, middleware.UserID(c)); err != nil {
+		panic(err)
+	}
+
+	return score
+}
+
 // Mount handlers to router group.
 func (s Score) Mount(router *gin.RouterGroup) {
 	router.GET("/", s.Index)
 	router.GET("/points", s.Points)
+	router.GET("/leaderboard", s.Leaderboard)
+	router.POST("/adjustments", middleware.Admin, s.Adjust)
 }
 
 // NewScore handler.
-func NewScore(repository rel.Repository) Score {
+func NewScore(scores scores.Service) Score {
 	return Score{
-		repository: repository,
+		scores: scores,
 	}
 }
@@ -1,101 +1,405 @@
 package handler_test
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
 	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestScore_Index(t *testing.T) {
 	tests := []struct {
-		name     string
-		status   int
-		path     string
-		response string
-		mockRepo func(repo *reltest.Repository)
+		name           string
+		status         int
+		path           string
+		response       string
+		mockScoresFind func(scores *scorestest.Service)
 	}{
 		{
 			name:     "ok",
 			status:   http.StatusOK,
 			path:     "/",
-			response: `{"id":1, "total_point":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
-			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind().Result(scores.Score{ID: 1, TotalPoint: 10})
-			},
+			response: `{"id":1, "user_id":1, "total_point":10, "current_streak":2, "longest_streak":5, "streak_on":"2026-10-14", "level":2, "level_progress":0, "next_level_at":25, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockScoresFind: scorestest.MockFind(
+				scores.Score{ID: 1, UserID: 1, TotalPoint: 10, CurrentStreak: 2, LongestStreak: 5, StreakOn: "2026-10-14", Level: 2, NextLevelAt: 25},
+				1,
+				nil,
+			),
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			var (
-				router     = gin.New()
-				repository = reltest.New()
-				handler    = handler.NewScore(repository)
-				req, _     = http.NewRequest("GET", test.path, nil)
-				rr         = httptest.NewRecorder()
+				router  = gin.New()
+				scores  = &scorestest.Service{}
+				handler = handler.NewScore(scores)
+				req, _  = http.NewRequest("GET", test.path, nil)
+				rr      = httptest.NewRecorder()
 			)
 
-			if test.mockRepo != nil {
-				test.mockRepo(repository)
-			}
+			scorestest.Mock(scores, test.mockScoresFind)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
 			assert.JSONEq(t, test.response, rr.Body.String())
 
-			repository.AssertExpectations(t)
+			scores.AssertExpectations(t)
 		})
 	}
 }
 
 func TestScore_Points(t *testing.T) {
+	var (
+		from      = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		to        = time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+		dedupeKey = "todo completed:todo:2"
+	)
+
 	tests := []struct {
-		name     string
-		status   int
-		path     string
-		response string
-		mockRepo func(repo *reltest.Repository)
+		name       string
+		status     int
+		path       string
+		response   string
+		link       string
+		mockScores func(scores *scorestest.Service)
 	}{
 		{
 			name:     "ok",
 			status:   http.StatusOK,
 			path:     "/points",
-			response: `[{"id":1, "name": "todo completed", "count":1, "score_id": 0, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
-			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFindAll().Result([]scores.Point{{ID: 1, Name: "todo completed", Count: 1}})
-			},
+			response: `[{"id":1, "name": "todo completed", "count":1, "score_id": 1, "source_type":"todo", "source_id":2, "dedupe_key":"todo completed:todo:2", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			mockScores: scorestest.MockPoints(
+				scores.PointPage{Points: []scores.Point{{ID: 1, Name: "todo completed", Count: 1, ScoreID: 1, SourceType: "todo", SourceID: 2, DedupeKey: &dedupeKey}}},
+				scores.PointFilter{UserID: 1},
+				nil,
+			),
+		},
+		{
+			name:     "adjusted",
+			status:   http.StatusOK,
+			path:     "/points",
+			response: `[{"id":2, "name": "score adjusted", "count":-5, "score_id": 1, "source_type":"adjustment", "source_id":3, "adjustment":{"id":3, "user_id":1, "count":-5, "reason":"duplicate todos", "adjusted_by":9, "forced":false, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			mockScores: scorestest.MockPoints(
+				scores.PointPage{Points: []scores.Point{{ID: 2, Name: scores.PointAdjusted, Count: -5, ScoreID: 1, SourceType: scores.SourceAdjustment, SourceID: 3, Adjustment: &scores.Adjustment{ID: 3, UserID: 1, Count: -5, Reason: "duplicate todos", AdjustedBy: 9}}}},
+				scores.PointFilter{UserID: 1},
+				nil,
+			),
+		},
+		{
+			name:       "no points yet",
+			status:     http.StatusOK,
+			path:       "/points",
+			response:   `[]`,
+			mockScores: scorestest.MockPoints(scores.PointPage{Points: []scores.Point{}}, scores.PointFilter{UserID: 1}, nil),
+		},
+		{
+			name:     "filtered with next page",
+			status:   http.StatusOK,
+			path:     "/points?name=todo+completed&from=2026-10-01T00:00:00Z&to=2026-10-15T00:00:00Z&cursor=10&limit=1",
+			response: `[{"id":9, "name": "todo completed", "count":1, "score_id": 1, "source_type":"", "source_id":0, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			link:     `</points?cursor=9&from=2026-10-01T00%3A00%3A00Z&limit=1&name=todo+completed&to=2026-10-15T00%3A00%3A00Z>; rel="next"`,
+			mockScores: scorestest.MockPoints(
+				scores.PointPage{Points: []scores.Point{{ID: 9, Name: "todo completed", Count: 1, ScoreID: 1}}, NextCursor: 9},
+				scores.PointFilter{UserID: 1, Name: "todo completed", From: from, To: to, Cursor: 10, Limit: 1},
+				nil,
+			),
+		},
+		{
+			name:     "by source",
+			status:   http.StatusOK,
+			path:     "/points?source_type=todo&source_id=2",
+			response: `[]`,
+			mockScores: scorestest.MockPoints(
+				scores.PointPage{Points: []scores.Point{}},
+				scores.PointFilter{UserID: 1, SourceType: "todo", SourceID: 2},
+				nil,
+			),
+		},
+		{
+			name:     "aggregate",
+			status:   http.StatusOK,
+			path:     "/points?aggregate=day",
+			response: `[{"bucket":"2026-10-14T00:00:00Z", "count":3}, {"bucket":"2026-10-13T00:00:00Z", "count":-1}]`,
+			mockScores: scorestest.MockPoints(
+				scores.PointPage{Buckets: []scores.PointBucket{{Bucket: to.AddDate(0, 0, -1), Count: 3}, {Bucket: to.AddDate(0, 0, -2), Count: -1}}},
+				scores.PointFilter{UserID: 1, Aggregate: "day"},
+				nil,
+			),
+		},
+		{
+			name:       "invalid aggregate",
+			status:     http.StatusBadRequest,
+			path:       "/points?aggregate=year",
+			response:   `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Aggregate must be one of day or week","instance":"/points"}`,
+			mockScores: scorestest.MockPoints(scores.PointPage{}, scores.PointFilter{UserID: 1, Aggregate: "year"}, scores.ErrPointAggregateInvalid),
+		},
+		{
+			name:     "invalid time",
+			status:   http.StatusBadRequest,
+			path:     "/points?from=yesterday",
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"","instance":"/points"}`,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			var (
-				router     = gin.New()
-				repository = reltest.New()
-				handler    = handler.NewScore(repository)
-				req, _     = http.NewRequest("GET", test.path, nil)
-				rr         = httptest.NewRecorder()
+				router  = gin.New()
+				scores  = &scorestest.Service{}
+				handler = handler.NewScore(scores)
+				req, _  = http.NewRequest("GET", test.path, nil)
+				rr      = httptest.NewRecorder()
 			)
 
-			if test.mockRepo != nil {
-				test.mockRepo(repository)
+			scorestest.Mock(scores, test.mockScores)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+			assert.Equal(t, test.link, rr.Header().Get("Link"))
+
+			scores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScore_Points_csv(t *testing.T) {
+	var (
+		mockScores = scorestest.MockPoints(
+			scores.PointPage{Points: []scores.Point{
+				{ID: 2, Name: "todo completed", Count: 1, ScoreID: 1, SourceType: "todo", SourceID: 2},
+				{ID: 1, Name: "streak", Count: 5, ScoreID: 1},
+			}},
+			scores.PointFilter{UserID: 1},
+			nil,
+		)
+		router  = gin.New()
+		scores  = &scorestest.Service{}
+		handler = handler.NewScore(scores)
+		req, _  = http.NewRequest("GET", "/points", nil)
+		rr      = httptest.NewRecorder()
+	)
+
+	scorestest.Mock(scores, mockScores)
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	req.Header.Set("Accept", "text/csv")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Equal(t, "id,name,count,score_id,source_type,source_id,created_at,updated_at\n"+
+		"2,todo completed,1,1,todo,2,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z\n"+
+		"1,streak,5,1,,0,0001-01-01T00:00:00Z,0001-01-01T00:00:00Z\n", rr.Body.String())
+
+	scores.AssertExpectations(t)
+}
+
+func TestScore_Adjust(t *testing.T) {
+	var (
+		adjustment = scores.Adjustment{UserID: 2, Count: -5, Reason: "duplicate todos", AdjustedBy: 1}
+	)
+
+	tests := []struct {
+		name       string
+		status     int
+		role       string
+		payload    string
+		response   string
+		isPanic    bool
+		mockScores func(scores *scorestest.Service)
+	}{
+		{
+			name:       "created",
+			status:     http.StatusCreated,
+			role:       "admin",
+			payload:    `{"user_id":2, "count":-5, "reason":"duplicate todos"}`,
+			response:   `{"id":3, "user_id":2, "count":-5, "reason":"duplicate todos", "adjusted_by":1, "forced":false, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockScores: scorestest.MockAdjust(scores.Adjustment{ID: 3, UserID: 2, Count: -5, Reason: "duplicate todos", AdjustedBy: 1}, adjustment, nil),
+		},
+		{
+			name:     "forced",
+			status:   http.StatusCreated,
+			role:     "admin",
+			payload:  `{"user_id":2, "count":-5, "reason":"abuse", "force":true}`,
+			response: `{"id":3, "user_id":2, "count":-5, "reason":"abuse", "adjusted_by":1, "forced":true, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockScores: scorestest.MockAdjust(
+				scores.Adjustment{ID: 3, UserID: 2, Count: -5, Reason: "abuse", AdjustedBy: 1, Forced: true},
+				scores.Adjustment{UserID: 2, Count: -5, Reason: "abuse", AdjustedBy: 1, Forced: true},
+				nil,
+			),
+		},
+		{
+			name:       "negative",
+			status:     http.StatusUnprocessableEntity,
+			role:       "admin",
+			payload:    `{"user_id":2, "count":-5, "reason":"duplicate todos"}`,
+			response:   `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Adjustment would make total point negative, force is required","instance":"/adjustments"}`,
+			mockScores: scorestest.MockAdjust(scores.Adjustment{}, adjustment, scores.ErrAdjustmentNegative),
+		},
+		{
+			name:     "reason blank",
+			status:   http.StatusUnprocessableEntity,
+			role:     "admin",
+			payload:  `{"user_id":2, "count":-5}`,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Reason can't be blank","instance":"/adjustments"}`,
+			mockScores: scorestest.MockAdjust(
+				scores.Adjustment{},
+				scores.Adjustment{UserID: 2, Count: -5, AdjustedBy: 1},
+				scores.ErrAdjustmentReasonBlank,
+			),
+		},
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			role:     "admin",
+			payload:  `{`,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Request body is malformed","instance":"/adjustments"}`,
+		},
+		{
+			name:     "forbidden",
+			status:   http.StatusForbidden,
+			payload:  `{"user_id":2, "count":-5, "reason":"duplicate todos"}`,
+			response: `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"Forbidden","instance":"/adjustments"}`,
+		},
+		{
+			name:       "panic",
+			role:       "admin",
+			payload:    `{"user_id":2, "count":-5, "reason":"duplicate todos"}`,
+			isPanic:    true,
+			mockScores: scorestest.MockAdjust(scores.Adjustment{}, adjustment, reltest.ErrConnectionClosed),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router  = gin.New()
+				scores  = &scorestest.Service{}
+				handler = handler.NewScore(scores)
+				body    = bytes.NewReader([]byte(test.payload))
+				req, _  = http.NewRequest("POST", "/adjustments", body)
+				rr      = httptest.NewRecorder()
+			)
+
+			scorestest.Mock(scores, test.mockScores)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			req.Header.Set(middleware.RoleHeader, test.role)
+			handler.Mount(router.Group("/", middleware.Auth))
+
+			if test.isPanic {
+				assert.Panics(t, func() {
+					router.ServeHTTP(rr, req)
+				})
+			} else {
+				router.ServeHTTP(rr, req)
+				assert.Equal(t, test.status, rr.Code)
+				assert.JSONEq(t, test.response, rr.Body.String())
 			}
 
-			handler.Mount(router.Group("/"))
+			scores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScore_Leaderboard(t *testing.T) {
+	var (
+		since = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name       string
+		status     int
+		path       string
+		response   string
+		mockScores func(scores *scorestest.Service)
+	}{
+		{
+			name:     "ok",
+			status:   http.StatusOK,
+			path:     "/leaderboard?window=day&limit=1",
+			response: `{"window":"day", "since":"2026-10-14T00:00:00Z", "entries":[{"rank":1, "user_id":2, "points":10}], "me":{"rank":3, "user_id":1, "points":2}}`,
+			mockScores: scorestest.MockLeaderboard(
+				scores.Leaderboard{
+					Window:  "day",
+					Since:   &since,
+					Entries: []scores.LeaderboardEntry{{Rank: 1, UserID: 2, Points: 10}},
+					Me:      scores.LeaderboardEntry{Rank: 3, UserID: 1, Points: 2},
+				},
+				1, "day", 1, nil,
+			),
+		},
+		{
+			name:     "default window",
+			status:   http.StatusOK,
+			path:     "/leaderboard",
+			response: `{"window":"all", "since":null, "entries":[], "me":{"rank":1, "user_id":1, "points":0}}`,
+			mockScores: scorestest.MockLeaderboard(
+				scores.Leaderboard{
+					Window:  "all",
+					Entries: []scores.LeaderboardEntry{},
+					Me:      scores.LeaderboardEntry{Rank: 1, UserID: 1},
+				},
+				1, "all", 0, nil,
+			),
+		},
+		{
+			name:       "invalid window",
+			status:     http.StatusBadRequest,
+			path:       "/leaderboard?window=year",
+			response:   `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Window must be one of day, week, month or all","instance":"/leaderboard"}`,
+			mockScores: scorestest.MockLeaderboard(scores.Leaderboard{}, 1, "year", 0, scores.ErrLeaderboardWindowInvalid),
+		},
+		{
+			name:     "csv is not supported",
+			status:   http.StatusNotAcceptable,
+			path:     "/leaderboard?format=csv",
+			response: `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"CSV format is only supported for list responses","instance":"/leaderboard"}`,
+			mockScores: scorestest.MockLeaderboard(
+				scores.Leaderboard{Window: "all", Entries: []scores.LeaderboardEntry{}, Me: scores.LeaderboardEntry{Rank: 1, UserID: 1}},
+				1, "all", 0, nil,
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router  = gin.New()
+				scores  = &scorestest.Service{}
+				handler = handler.NewScore(scores)
+				req, _  = http.NewRequest("GET", test.path, nil)
+				rr      = httptest.NewRecorder()
+			)
+
+			scorestest.Mock(scores, test.mockScores)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
 			assert.JSONEq(t, test.response, rr.Body.String())
 
-			repository.AssertExpectations(t)
+			scores.AssertExpectations(t)
 		})
 	}
 }
@@ -1,11 +1,13 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
 	"github.com/go-rel/gin-example/todos"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/where"
@@ -29,6 +31,7 @@ func (t Todos) Index(c *gin.Context) {
 	var (
 		result []todos.Todo
 		filter = todos.Filter{
+			UserID:  middleware.UserID(c),
 			Keyword: c.Query("keyword"),
 		}
 	)
@@ -39,7 +42,17 @@ func (t Todos) Index(c *gin.Context) {
 	}
 
 	t.todos.Search(c, &result, filter)
-	render(c, result, 200)
+
+	list := make([]interface{}, len(result))
+	for i := range result {
+		if err := renderNotes(c, &result[i]); err != nil {
+			panic(err)
+		}
+
+		list[i] = present(c, result[i])
+	}
+
+	render(c, list, 200)
 }
 
 // Create handle POST /
@@ -50,17 +63,54 @@ func (t Todos) Create(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&todo); err != nil {
 		logger.Warn("decode error", zap.Error(err))
-		render(c, ErrBadRequest, 400)
+		renderError(c, problem.Decode(err))
 		return
 	}
 
+	todo.UserID = middleware.UserID(c)
 	if err := t.todos.Create(c, &todo); err != nil {
-		render(c, err, 422)
+		renderError(c, err)
 		return
 	}
 
+	if err := renderNotes(c, &todo); err != nil {
+		panic(err)
+	}
+
 	c.Header("Location", fmt.Sprint(c.Request.RequestURI, "/", todo.ID))
-	render(c, todo, 201)
+	render(c, present(c, todo), 201)
+}
+
+// Quick handle POST /quick
+func (t Todos) Quick(c *gin.Context) {
+	var (
+		todo    todos.Todo
+		request struct {
+			Text string `json:"text"`
+		}
+	)
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	todo.UserID = middleware.UserID(c)
+	tokens, err := t.todos.Quick(c, &todo, request.Text)
+	if err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprint(strings.TrimSuffix(c.Request.URL.Path, "/quick"), "/", todo.ID))
+	render(c, struct {
+		Todo   interface{}   `json:"todo"`
+		Tokens []todos.Token `json:"tokens"`
+	}{
+		Todo:   present(c, todo),
+		Tokens: tokens,
+	}, 201)
 }
 
 // Show handle GET /{ID}
@@ -69,7 +119,11 @@ func (t Todos) Show(c *gin.Context) {
 		todo = c.MustGet(loadKey).(todos.Todo)
 	)
 
-	render(c, todo, 200)
+	if err := renderNotes(c, &todo); err != nil {
+		panic(err)
+	}
+
+	render(c, present(c, todo), 200)
 }
 
 // Update handle PATCH /{ID}
@@ -81,16 +135,21 @@ func (t Todos) Update(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&todo); err != nil {
 		logger.Warn("decode error", zap.Error(err))
-		render(c, ErrBadRequest, 400)
+		renderError(c, problem.Decode(err))
 		return
 	}
 
+	todo.UserID = middleware.UserID(c)
 	if err := t.todos.Update(c, &todo, changes); err != nil {
-		render(c, err, 422)
+		renderError(c, err)
 		return
 	}
 
-	render(c, todo, 200)
+	if err := renderNotes(c, &todo); err != nil {
+		panic(err)
+	}
+
+	render(c, present(c, todo), 200)
 }
 
 // Destroy handle DELETE /{ID}
@@ -105,7 +164,7 @@ func (t Todos) Destroy(c *gin.Context) {
 
 // Clear handle DELETE /
 func (t Todos) Clear(c *gin.Context) {
-	t.todos.Clear(c)
+	t.todos.Clear(c, middleware.UserID(c))
 	render(c, nil, 204)
 }
 
@@ -116,23 +175,44 @@ func (t Todos) Load(c *gin.Context) {
 		todo  todos.Todo
 	)
 
-	if err := t.repository.Find(c, &todo, where.Eq("id", id)); err != nil {
-		if errors.Is(err, rel.ErrNotFound) {
-			render(c, err, 404)
-			c.Abort()
-			return
-		}
-		panic(err)
+	if err := t.repository.Find(c, &todo, where.Eq("id", id).AndEq("user_id", middleware.UserID(c))); err != nil {
+		renderError(c, err)
+		return
 	}
 
 	c.Set(loadKey, todo)
 	c.Next()
 }
 
+// present todo in the representation of the requested api version.
+// Unversioned routes use the todo as is, which has the url of unversioned routes.
+func present(c *gin.Context, todo todos.Todo) interface{} {
+	switch middleware.APIVersion(c) {
+	case middleware.VersionV1:
+		return newTodoV1(todo)
+	case middleware.VersionV2:
+		return newTodoV2(todo)
+	default:
+		return todo
+	}
+}
+
+// renderNotes fills sanitized html notes when requested using ?render=html.
+// html notes is always generated by server, any value sent by client is discarded.
+func renderNotes(c *gin.Context, todo *todos.Todo) error {
+	if c.Query("render") != "html" {
+		todo.NotesHTML = ""
+		return nil
+	}
+
+	return todo.RenderNotes()
+}
+
 // Mount handlers to router group.
 func (t Todos) Mount(router *gin.RouterGroup) {
 	router.GET("/", t.Index)
 	router.POST("/", t.Create)
+	router.POST("/quick", t.Quick)
 	router.GET("/:ID", t.Load, t.Show)
 	router.PATCH("/:ID", t.Load, t.Update)
 	router.DELETE("/:ID", t.Load, t.Destroy)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
 	"github.com/go-rel/gin-example/todos"
 	"github.com/go-rel/gin-example/todos/todostest"
 	"github.com/go-rel/rel/where"
@@ -31,10 +32,10 @@ func TestTodos_Index(t *testing.T) {
 			name:     "ok",
 			status:   http.StatusOK,
 			path:     "/",
-			response: `[{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			response: `[{"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
 			mockTodosSearch: todostest.MockSearch(
-				[]todos.Todo{{ID: 1, Title: "Sleep"}},
-				todos.Filter{},
+				[]todos.Todo{{ID: 1, UserID: 1, Title: "Sleep"}},
+				todos.Filter{UserID: 1},
 				nil,
 			),
 		},
@@ -42,10 +43,21 @@ func TestTodos_Index(t *testing.T) {
 			name:     "with keyword and filter completed",
 			status:   http.StatusOK,
 			path:     "/?keyword=Wake&completed=true",
-			response: `[{"id":2, "title":"Wake", "completed":true, "order":0, "url":"todos/2", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			response: `[{"id":2, "user_id":1, "title":"Wake", "notes":"", "completed":true, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/2", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
 			mockTodosSearch: todostest.MockSearch(
-				[]todos.Todo{{ID: 2, Title: "Wake", Completed: true}},
-				todos.Filter{Keyword: "Wake", Completed: &trueb},
+				[]todos.Todo{{ID: 2, UserID: 1, Title: "Wake", Completed: true}},
+				todos.Filter{UserID: 1, Keyword: "Wake", Completed: &trueb},
+				nil,
+			),
+		},
+		{
+			name:     "render notes as html",
+			status:   http.StatusOK,
+			path:     "/?render=html",
+			response: `[{"id":1, "user_id":1, "title":"Sleep", "notes":"*zzz*", "notes_html":"<p><em>zzz</em></p>\n", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			mockTodosSearch: todostest.MockSearch(
+				[]todos.Todo{{ID: 1, UserID: 1, Title: "Sleep", Notes: "*zzz*"}},
+				todos.Filter{UserID: 1},
 				nil,
 			),
 		},
@@ -64,7 +76,8 @@ func TestTodos_Index(t *testing.T) {
 
 			todostest.Mock(todos, test.mockTodosSearch)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
@@ -76,6 +89,68 @@ func TestTodos_Index(t *testing.T) {
 	}
 }
 
+func TestTodos_Index_format(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		contentType string
+		response    string
+	}{
+		{
+			name:        "csv",
+			path:        "/?format=csv",
+			contentType: "text/csv; charset=utf-8",
+			response:    "id,user_id,title,notes,order,completed,due_at,priority,list,tags,created_at,updated_at,url\n1,1,Sleep,,0,false,,0,,\"[\"\"home\"\"]\",0001-01-01T00:00:00Z,0001-01-01T00:00:00Z,todos/1\n",
+		},
+		{
+			name:        "xml",
+			path:        "/",
+			accept:      "application/xml",
+			contentType: "application/xml; charset=utf-8",
+			response:    `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<response><item><id>1</id><user_id>1</user_id><title>Sleep</title><notes></notes><order>0</order><completed>false</completed><due_at></due_at><priority>0</priority><list></list><tags><item>home</item></tags><created_at>0001-01-01T00:00:00Z</created_at><updated_at>0001-01-01T00:00:00Z</updated_at><url>todos/1</url></item></response>`,
+		},
+		{
+			name:        "yaml",
+			path:        "/",
+			accept:      "application/yaml",
+			contentType: "application/yaml; charset=utf-8",
+			response:    "- id: 1\n  user_id: 1\n  title: Sleep\n  notes: \"\"\n  order: 0\n  completed: false\n  due_at: null\n  priority: 0\n  list: \"\"\n  tags:\n    - home\n  created_at: \"0001-01-01T00:00:00Z\"\n  updated_at: \"0001-01-01T00:00:00Z\"\n  url: todos/1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				mockTodosSearch = todostest.MockSearch(
+					[]todos.Todo{{ID: 1, UserID: 1, Title: "Sleep", Tags: todos.Tags{"home"}}},
+					todos.Filter{UserID: 1},
+					nil,
+				)
+				router     = gin.New()
+				req, _     = http.NewRequest("GET", test.path, nil)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				handler    = handler.NewTodos(repository, todos)
+			)
+
+			todostest.Mock(todos, mockTodosSearch)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			req.Header.Set("Accept", test.accept)
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, test.contentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, test.response, rr.Body.String())
+
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTodos_Create(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -91,10 +166,10 @@ func TestTodos_Create(t *testing.T) {
 			status:   http.StatusCreated,
 			path:     "/",
 			payload:  `{"title": "Sleep"}`,
-			response: `{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			response: `{"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			location: "/1",
 			mockTodosCreate: todostest.MockCreate(
-				todos.Todo{ID: 1, Title: "Sleep"},
+				todos.Todo{ID: 1, UserID: 1, Title: "Sleep"},
 				nil,
 			),
 		},
@@ -103,7 +178,7 @@ func TestTodos_Create(t *testing.T) {
 			status:   http.StatusUnprocessableEntity,
 			path:     "/",
 			payload:  `{"title": ""}`,
-			response: `{"error":"Title can't be blank"}`,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Title can't be blank","instance":"/"}`,
 			mockTodosCreate: todostest.MockCreate(
 				todos.Todo{Title: "Sleep"},
 				todos.ErrTodoTitleBlank,
@@ -114,7 +189,7 @@ func TestTodos_Create(t *testing.T) {
 			status:   http.StatusBadRequest,
 			path:     "/",
 			payload:  ``,
-			response: `{"error":"Bad Request"}`,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Request body is empty","instance":"/"}`,
 		},
 	}
 
@@ -132,7 +207,82 @@ func TestTodos_Create(t *testing.T) {
 
 			todostest.Mock(todos, test.mockTodosCreate)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.location, rr.Header().Get("Location"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTodos_Quick(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		path           string
+		payload        string
+		response       string
+		location       string
+		mockTodosQuick func(todos *todostest.Service)
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			path:     "/quick",
+			payload:  `{"text": "Sleep #home !high"}`,
+			response: `{"todo": {"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":3, "list":"", "tags":["home"], "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}, "tokens": [{"type":"tag", "text":"#home"}, {"type":"priority", "text":"!high"}]}`,
+			location: "/1",
+			mockTodosQuick: todostest.MockQuick(
+				"Sleep #home !high",
+				todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Priority: todos.PriorityHigh, Tags: todos.Tags{"home"}},
+				[]todos.Token{{Type: todos.TokenTag, Text: "#home"}, {Type: todos.TokenPriority, Text: "!high"}},
+				nil,
+			),
+		},
+		{
+			name:     "validation error",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/quick",
+			payload:  `{"text": "#home"}`,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Title can't be blank","instance":"/quick"}`,
+			mockTodosQuick: todostest.MockQuick(
+				"#home",
+				todos.Todo{Tags: todos.Tags{"home"}},
+				[]todos.Token{{Type: todos.TokenTag, Text: "#home"}},
+				todos.ErrTodoTitleBlank,
+			),
+		},
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			path:     "/quick",
+			payload:  ``,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Request body is empty","instance":"/quick"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router     = gin.New()
+				body       = strings.NewReader(test.payload)
+				req, _     = http.NewRequest("POST", test.path, body)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				handler    = handler.NewTodos(repository, todos)
+			)
+
+			todostest.Mock(todos, test.mockTodosQuick)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
@@ -158,18 +308,27 @@ func TestTodos_Show(t *testing.T) {
 			name:     "ok",
 			status:   http.StatusOK,
 			path:     "/1",
-			response: `{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			response: `{"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+		},
+		{
+			name:     "render notes as html",
+			status:   http.StatusOK,
+			path:     "/1?render=html",
+			response: `{"id":1, "user_id":1, "title":"Sleep", "notes":"<img src=x onerror=alert(1)>[x](javascript:alert(1))", "notes_html":"<p>x</p>\n", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Notes: "<img src=x onerror=alert(1)>[x](javascript:alert(1))"})
 			},
 		},
 		{
 			name:     "not found",
 			status:   http.StatusNotFound,
 			path:     "/1",
-			response: `{"error":"entity not found"}`,
+			response: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"entity not found","instance":"/1"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).NotFound()
 			},
 		},
 		{
@@ -177,7 +336,7 @@ func TestTodos_Show(t *testing.T) {
 			path:    "/1",
 			isPanic: true,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).ConnectionClosed()
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).ConnectionClosed()
 			},
 		},
 	}
@@ -197,7 +356,8 @@ func TestTodos_Show(t *testing.T) {
 				test.mockRepo(repository)
 			}
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 
 			if test.isPanic {
 				assert.Panics(t, func() {
@@ -215,6 +375,50 @@ func TestTodos_Show(t *testing.T) {
 	}
 }
 
+func TestTodos_Show_version(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		response string
+	}{
+		{
+			name:     "v1",
+			version:  middleware.VersionV1,
+			response: `{"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":3, "list":"", "tags":null, "url":"v1/todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:     "v2",
+			version:  middleware.VersionV2,
+			response: `{"id":1, "user_id":1, "title":"Sleep", "notes":"", "completed":false, "order":0, "due_at":null, "priority":"high", "list":"", "tags":[], "url":"v2/todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				todo       = todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Priority: todos.PriorityHigh}
+				router     = gin.New()
+				req, _     = http.NewRequest("GET", "/1", nil)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				handler    = handler.NewTodos(repository, todos)
+			)
+
+			repository.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todo)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Version(test.version), middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
 func TestTodos_Update(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -230,12 +434,12 @@ func TestTodos_Update(t *testing.T) {
 			status:   http.StatusOK,
 			path:     "/1",
 			payload:  `{"title": "Wake"}`,
-			response: `{"id":1, "title":"Wake", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			response: `{"id":1, "user_id":1, "title":"Wake", "notes":"", "completed":false, "order":0, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
 			},
 			mockTodosUpdate: todostest.MockUpdate(
-				todos.Todo{ID: 1, Title: "Wake"},
+				todos.Todo{ID: 1, UserID: 1, Title: "Wake"},
 				nil,
 			),
 		},
@@ -244,12 +448,12 @@ func TestTodos_Update(t *testing.T) {
 			status:   http.StatusUnprocessableEntity,
 			path:     "/1",
 			payload:  `{"title": ""}`,
-			response: `{"error":"Title can't be blank"}`,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Title can't be blank","instance":"/1"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
 			},
 			mockTodosUpdate: todostest.MockUpdate(
-				todos.Todo{ID: 1, Title: ""},
+				todos.Todo{ID: 1, UserID: 1, Title: ""},
 				todos.ErrTodoTitleBlank,
 			),
 		},
@@ -258,9 +462,9 @@ func TestTodos_Update(t *testing.T) {
 			status:   http.StatusBadRequest,
 			path:     "/1",
 			payload:  ``,
-			response: `{"error":"Bad Request"}`,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Request body is empty","instance":"/1"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
 			},
 		},
 	}
@@ -283,7 +487,8 @@ func TestTodos_Update(t *testing.T) {
 
 			todostest.Mock(todos, test.mockTodosUpdate)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
@@ -310,7 +515,7 @@ func TestTodos_Destroy(t *testing.T) {
 			path:     "/1",
 			response: "",
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
 			},
 			mockTodosDelete: todostest.MockDelete(),
 		},
@@ -333,7 +538,8 @@ func TestTodos_Destroy(t *testing.T) {
 
 			todostest.Mock(todos, test.mockTodosDelete)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
@@ -358,7 +564,7 @@ func TestTodos_Clear(t *testing.T) {
 			status:         http.StatusNoContent,
 			path:           "/",
 			response:       "",
-			mockTodosClear: todostest.MockClear(),
+			mockTodosClear: todostest.MockClear(1),
 		},
 	}
 
@@ -375,7 +581,8 @@ func TestTodos_Clear(t *testing.T) {
 
 			todostest.Mock(todos, test.mockTodosClear)
 
-			handler.Mount(router.Group("/"))
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
 			router.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
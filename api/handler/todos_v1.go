@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/todos"
+)
+
+// todoV1 is todo representation of api v1, the same as todo with url of v1 routes.
+type todoV1 struct {
+	todos.Todo
+	URL string
+}
+
+func newTodoV1(todo todos.Todo) todoV1 {
+	return todoV1{
+		Todo: todo,
+		URL:  fmt.Sprint(todos.TodoURLPrefix(middleware.VersionV1), todo.ID),
+	}
+}
+
+// MarshalJSON implement custom marshaller, since marshaller of the embedded todo would marshal unversioned url.
+func (t todoV1) MarshalJSON() ([]byte, error) {
+	type Alias todos.Todo
+
+	return json.Marshal(struct {
+		Alias
+		URL string `json:"url"`
+	}{
+		Alias: Alias(t.Todo),
+		URL:   t.URL,
+	})
+}
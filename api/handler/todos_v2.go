@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/todos"
+)
+
+// todoV2 is todo representation of api v2, priority is represented by its name and tags is always a list.
+type todoV2 struct {
+	ID        uint       `json:"id"`
+	UserID    int        `json:"user_id"`
+	Title     string     `json:"title"`
+	Notes     string     `json:"notes"`
+	NotesHTML string     `json:"notes_html,omitempty"`
+	Order     int        `json:"order"`
+	Completed bool       `json:"completed"`
+	DueAt     *time.Time `json:"due_at"`
+	Priority  string     `json:"priority"`
+	List      string     `json:"list"`
+	Tags      []string   `json:"tags"`
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func newTodoV2(todo todos.Todo) todoV2 {
+	return todoV2{
+		ID:        todo.ID,
+		UserID:    todo.UserID,
+		Title:     todo.Title,
+		Notes:     todo.Notes,
+		NotesHTML: todo.NotesHTML,
+		Order:     todo.Order,
+		Completed: todo.Completed,
+		DueAt:     todo.DueAt,
+		Priority:  todo.Priority.String(),
+		List:      todo.List,
+		Tags:      append([]string{}, todo.Tags...),
+		URL:       fmt.Sprint(todos.TodoURLPrefix(middleware.VersionV2), todo.ID),
+		CreatedAt: todo.CreatedAt,
+		UpdatedAt: todo.UpdatedAt,
+	}
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/webhooks"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookLoadKey string = "webhooksLoadKey"
+)
+
+// Webhooks for webhooks endpoints.
+// Secret of a webhook is only rendered when it's created.
+type Webhooks struct {
+	repository rel.Repository
+	webhooks   webhooks.Service
+}
+
+// Index handle GET /.
+func (w Webhooks) Index(c *gin.Context) {
+	var (
+		result []webhooks.Webhook
+	)
+
+	if err := w.webhooks.Search(c, &result, middleware.UserID(c)); err != nil {
+		panic(err)
+	}
+
+	for i := range result {
+		result[i].Secret = ""
+	}
+
+	render(c, result, 200)
+}
+
+// Create handle POST /
+func (w Webhooks) Create(c *gin.Context) {
+	var (
+		webhook webhooks.Webhook
+	)
+
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	webhook.UserID = middleware.UserID(c)
+	if err := w.webhooks.Create(c, &webhook); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprint(strings.TrimSuffix(c.Request.URL.Path, "/"), "/", webhook.ID))
+	render(c, webhook, 201)
+}
+
+// Show handle GET /{ID}
+func (w Webhooks) Show(c *gin.Context) {
+	var (
+		webhook = c.MustGet(webhookLoadKey).(webhooks.Webhook)
+	)
+
+	webhook.Secret = ""
+	render(c, webhook, 200)
+}
+
+// Update handle PATCH /{ID}
+func (w Webhooks) Update(c *gin.Context) {
+	var (
+		loaded  = c.MustGet(webhookLoadKey).(webhooks.Webhook)
+		webhook = loaded
+		changes = rel.NewChangeset(&webhook)
+	)
+
+	if err := c.ShouldBindJSON(&webhook); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		renderError(c, problem.Decode(err))
+		return
+	}
+
+	// failures are tracked by deliveries, any value sent by client is discarded.
+	webhook.ID, webhook.UserID = loaded.ID, loaded.UserID
+	webhook.Failures, webhook.DisabledAt = loaded.Failures, loaded.DisabledAt
+	if err := w.webhooks.Update(c, &webhook, changes); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	webhook.Secret = ""
+	render(c, webhook, 200)
+}
+
+// Destroy handle DELETE /{ID}
+func (w Webhooks) Destroy(c *gin.Context) {
+	var (
+		webhook = c.MustGet(webhookLoadKey).(webhooks.Webhook)
+	)
+
+	w.webhooks.Delete(c, &webhook)
+	render(c, nil, 204)
+}
+
+// Deliveries handle GET /{ID}/deliveries
+func (w Webhooks) Deliveries(c *gin.Context) {
+	var (
+		webhook = c.MustGet(webhookLoadKey).(webhooks.Webhook)
+		result  []webhooks.Delivery
+	)
+
+	if err := w.webhooks.Deliveries(c, &result, webhook); err != nil {
+		panic(err)
+	}
+
+	render(c, result, 200)
+}
+
+// Load is middleware that loads webhook of the user to context.
+func (w Webhooks) Load(c *gin.Context) {
+	var (
+		id, _   = strconv.Atoi(c.Param("ID"))
+		webhook webhooks.Webhook
+	)
+
+	if err := w.repository.Find(c, &webhook, where.Eq("id", id).AndEq("user_id", middleware.UserID(c))); err != nil {
+		renderError(c, err)
+		return
+	}
+
+	c.Set(webhookLoadKey, webhook)
+	c.Next()
+}
+
+// Mount handlers to router group.
+func (w Webhooks) Mount(router *gin.RouterGroup) {
+	router.GET("/", w.Index)
+	router.POST("/", w.Create)
+	router.GET("/:ID", w.Load, w.Show)
+	router.PATCH("/:ID", w.Load, w.Update)
+	router.DELETE("/:ID", w.Load, w.Destroy)
+	router.GET("/:ID/deliveries", w.Load, w.Deliveries)
+}
+
+// NewWebhooks handler.
+func NewWebhooks(repository rel.Repository, webhooks webhooks.Service) Webhooks {
+	return Webhooks{
+		repository: repository,
+		webhooks:   webhooks,
+	}
+}
@@ -0,0 +1,266 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/webhooks"
+	"github.com/go-rel/gin-example/webhooks/webhookstest"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhooks_Index(t *testing.T) {
+	var (
+		router      = gin.New()
+		req, _      = http.NewRequest("GET", "/", nil)
+		rr          = httptest.NewRecorder()
+		repository  = reltest.New()
+		webhooksSvc = &webhookstest.Service{}
+		handler     = handler.NewWebhooks(repository, webhooksSvc)
+	)
+
+	webhookstest.Mock(webhooksSvc, webhookstest.MockSearch([]webhooks.Webhook{{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true}}, 1, nil))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":1, "user_id":1, "url":"https://example.com/hooks", "events":null, "enabled":true, "failures":0, "disabled_at":null, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	webhooksSvc.AssertExpectations(t)
+}
+
+func TestWebhooks_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		payload      string
+		response     string
+		location     string
+		mockWebhooks func(webhooks *webhookstest.Service)
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			payload:  `{"url": "https://example.com/hooks", "events": ["todo.created"]}`,
+			response: `{"id":1, "user_id":1, "url":"https://example.com/hooks", "secret":"0123456789abcdef", "events":["todo.created"], "enabled":true, "failures":0, "disabled_at":null, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			location: "/1",
+			mockWebhooks: webhookstest.MockCreate(
+				webhooks.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Events: webhooks.Filter{"todo.created"}, Enabled: true},
+				nil,
+			),
+		},
+		{
+			name:     "validation error",
+			status:   http.StatusUnprocessableEntity,
+			payload:  `{"url": "example.com"}`,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"URL must be an absolute http or https url","instance":"/"}`,
+			mockWebhooks: webhookstest.MockCreate(
+				webhooks.Webhook{URL: "example.com"},
+				webhooks.ErrWebhookURLInvalid,
+			),
+		},
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			payload:  ``,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"Request body is empty","instance":"/"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router      = gin.New()
+				body        = strings.NewReader(test.payload)
+				req, _      = http.NewRequest("POST", "/", body)
+				rr          = httptest.NewRecorder()
+				repository  = reltest.New()
+				webhooksSvc = &webhookstest.Service{}
+				handler     = handler.NewWebhooks(repository, webhooksSvc)
+			)
+
+			webhookstest.Mock(webhooksSvc, test.mockWebhooks)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.location, rr.Header().Get("Location"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+			webhooksSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhooks_Show(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		path     string
+		response string
+		mockRepo func(repo *reltest.Repository)
+	}{
+		{
+			name:     "ok",
+			status:   http.StatusOK,
+			path:     "/1",
+			response: `{"id":1, "user_id":1, "url":"https://example.com/hooks", "events":null, "enabled":true, "failures":0, "disabled_at":null, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(webhooks.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true})
+			},
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			path:     "/2",
+			response: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"entity not found","instance":"/2"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 2).AndEq("user_id", 1)).NotFound()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router     = gin.New()
+				req, _     = http.NewRequest("GET", test.path, nil)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				handler    = handler.NewWebhooks(repository, &webhookstest.Service{})
+			)
+
+			test.mockRepo(repository)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			handler.Mount(router.Group("/", middleware.Auth))
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhooks_Update(t *testing.T) {
+	var (
+		router      = gin.New()
+		body        = strings.NewReader(`{"enabled": true, "failures": 0, "user_id": 2}`)
+		req, _      = http.NewRequest("PATCH", "/1", body)
+		rr          = httptest.NewRecorder()
+		repository  = reltest.New()
+		webhooksSvc = &webhookstest.Service{}
+		handler     = handler.NewWebhooks(repository, webhooksSvc)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(webhooks.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Failures: webhooks.MaxFailures})
+
+	// failures and owner can't be changed by client, failures are reset by the service when it's enabled.
+	webhooksSvc.On("Update", mock.Anything, mock.MatchedBy(func(webhook *webhooks.Webhook) bool {
+		return webhook.Enabled && webhook.UserID == 1 && webhook.Failures == webhooks.MaxFailures
+	}), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*webhooks.Webhook).Failures = 0
+	})
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":1, "user_id":1, "url":"https://example.com/hooks", "events":null, "enabled":true, "failures":0, "disabled_at":null, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	webhooksSvc.AssertExpectations(t)
+}
+
+func TestWebhooks_Update_validationError(t *testing.T) {
+	var (
+		router      = gin.New()
+		body        = strings.NewReader(`{"events": ["todo.completed"]}`)
+		req, _      = http.NewRequest("PATCH", "/1", body)
+		rr          = httptest.NewRecorder()
+		repository  = reltest.New()
+		webhooksSvc = &webhookstest.Service{}
+		handler     = handler.NewWebhooks(repository, webhooksSvc)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(webhooks.Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true})
+	webhookstest.Mock(webhooksSvc, webhookstest.MockUpdate(webhooks.Webhook{ID: 1}, webhooks.ErrWebhookEventUnsupported))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	assert.JSONEq(t, `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Event is not supported","instance":"/1"}`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	webhooksSvc.AssertExpectations(t)
+}
+
+func TestWebhooks_Destroy(t *testing.T) {
+	var (
+		router      = gin.New()
+		req, _      = http.NewRequest("DELETE", "/1", nil)
+		rr          = httptest.NewRecorder()
+		repository  = reltest.New()
+		webhooksSvc = &webhookstest.Service{}
+		handler     = handler.NewWebhooks(repository, webhooksSvc)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(webhooks.Webhook{ID: 1, UserID: 1})
+	webhookstest.Mock(webhooksSvc, webhookstest.MockDelete())
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "", rr.Body.String())
+
+	repository.AssertExpectations(t)
+	webhooksSvc.AssertExpectations(t)
+}
+
+func TestWebhooks_Deliveries(t *testing.T) {
+	var (
+		router      = gin.New()
+		req, _      = http.NewRequest("GET", "/1/deliveries", nil)
+		rr          = httptest.NewRecorder()
+		repository  = reltest.New()
+		webhooksSvc = &webhookstest.Service{}
+		handler     = handler.NewWebhooks(repository, webhooksSvc)
+		webhook     = webhooks.Webhook{ID: 1, UserID: 1}
+	)
+
+	repository.ExpectFind(where.Eq("id", 1).AndEq("user_id", 1)).Result(webhook)
+	webhookstest.Mock(webhooksSvc, webhookstest.MockDeliveries([]webhooks.Delivery{
+		{ID: 2, WebhookID: 1, Event: "todo.created", Payload: `{"name":"todo.created"}`, Status: webhooks.DeliveryFailed, Attempts: webhooks.MaxAttempts, ResponseStatus: 500, Error: "Receiver responded with status 500"},
+	}, webhook, nil))
+
+	req.Header.Set(middleware.UserIDHeader, "1")
+	handler.Mount(router.Group("/", middleware.Auth))
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":2, "webhook_id":1, "event":"todo.created", "payload":"{\"name\":\"todo.created\"}", "status":"failed", "attempts":8, "next_attempt_at":null, "response_status":500, "error":"Receiver responded with status 500", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	webhooksSvc.AssertExpectations(t)
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// Types of websocket message.
+const (
+	// MessageSubscribe starts receiving changes, from last_event_id when it's set.
+	MessageSubscribe = "subscribe"
+	// MessageCreate creates todo.
+	MessageCreate = "create"
+	// MessageUpdate updates fields of todo_id present in todo.
+	MessageUpdate = "update"
+	// MessageDelete deletes todo_id.
+	MessageDelete = "delete"
+	// MessageAck is sent when message of the client is handled, with the todo and its server id.
+	MessageAck = "ack"
+	// MessageError is sent when message of the client is rejected, with the error as problem details.
+	MessageError = "error"
+	// MessageEvent is sent for every change of a subscribed user.
+	MessageEvent = "event"
+	// MessageReset is sent when events after last_event_id are no longer buffered, client should reload its state.
+	MessageReset = "reset"
+)
+
+const (
+	// DefaultWebSocketQueue is the number of messages queued for a connection before enqueue waits for the writer.
+	DefaultWebSocketQueue = 64
+	// wsWriteWait is the time allowed to write a message.
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is the time allowed to read the next message or pong.
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod must be less than wsPongWait.
+	wsPingPeriod = wsPongWait * 9 / 10
+	// wsMaxMessageSize is the maximum size of message sent by client.
+	wsMaxMessageSize = 64 * 1024
+)
+
+var (
+	// ErrMessageTypeUnsupported error.
+	ErrMessageTypeUnsupported = errors.New("Message type is not supported")
+	// ErrMessageTodoRequired error.
+	ErrMessageTodoRequired = errors.New("Todo is required")
+)
+
+// Message of websocket sync protocol, fields are set according to its type.
+type Message struct {
+	Type string `json:"type"`
+	// ID is chosen by client, ack and error of the message are sent with the same id.
+	ID          string           `json:"id,omitempty"`
+	TodoID      uint             `json:"todo_id,omitempty"`
+	Todo        json.RawMessage  `json:"todo,omitempty"`
+	LastEventID *int64           `json:"last_event_id,omitempty"`
+	EventID     int64            `json:"event_id,omitempty"`
+	Event       *events.Event    `json:"event,omitempty"`
+	Error       *problem.Problem `json:"error,omitempty"`
+}
+
+// WebSocket for realtime sync endpoint.
+type WebSocket struct {
+	repository rel.Repository
+	todos      todos.Service
+	buffer     *events.Buffer
+	limiter    middleware.Limiter
+	upgrader   websocket.Upgrader
+	queue      int
+}
+
+// Connect handle GET /
+// Mutations are handled one at a time in the order they're received, changes are sent once the client subscribes.
+func (w WebSocket) Connect(c *gin.Context) {
+	conn, err := w.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// upgrader already responded with the error.
+		logger.Warn("websocket upgrade error", zap.Error(err))
+		return
+	}
+
+	var (
+		wait, cancel = w.buffer.Wait()
+		s            = &session{
+			WebSocket: w,
+			c:         c,
+			conn:      conn,
+			userID:    middleware.UserID(c),
+			send:      make(chan Message, w.queue),
+			subscribe: make(chan Message),
+			done:      make(chan struct{}),
+		}
+	)
+
+	defer cancel()
+
+	go s.write()
+	go s.pump(wait)
+	s.read()
+}
+
+// Mount handlers to router group.
+func (w WebSocket) Mount(router *gin.RouterGroup) {
+	router.GET("", w.Connect)
+}
+
+// NewWebSocket handler queueing at most queue messages for each connection, DefaultWebSocketQueue is used when it's not positive.
+// Mutations are limited by the limiter the same as requests to their todos route, eg. create by "POST /todos".
+func NewWebSocket(repository rel.Repository, todos todos.Service, buffer *events.Buffer, limiter middleware.Limiter, queue int) WebSocket {
+	if queue <= 0 {
+		queue = DefaultWebSocketQueue
+	}
+
+	return WebSocket{
+		repository: repository,
+		todos:      todos,
+		buffer:     buffer,
+		limiter:    limiter,
+		queue:      queue,
+	}
+}
+
+// session of a websocket connection.
+// Reader handles client messages, writer is the only one writing to the connection and pump forwards subscribed events.
+type session struct {
+	WebSocket
+	c         *gin.Context
+	conn      *websocket.Conn
+	userID    int
+	send      chan Message
+	subscribe chan Message
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// read and handle client messages until the connection is closed.
+func (s *session) read() {
+	defer s.close(websocket.CloseNormalClosure, "")
+
+	s.conn.SetReadLimit(wsMaxMessageSize)
+	s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Warn("websocket read error", zap.Error(err))
+			}
+
+			return
+		}
+
+		s.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var message Message
+		if err := json.Unmarshal(data, &message); err != nil {
+			logger.Warn("decode error", zap.Error(err))
+			s.fail(message, problem.BadRequest(errors.New("Message is malformed")))
+			continue
+		}
+
+		s.handle(message)
+	}
+}
+
+// handle message of the client, panic is recovered so it doesn't end the connection.
+func (s *session) handle(message Message) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.fail(message, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	var (
+		ctx  = s.c.Request.Context()
+		todo todos.Todo
+		err  error
+	)
+
+	switch message.Type {
+	case MessageSubscribe:
+		// pump acks the subscription, so it's received before any event.
+		select {
+		case s.subscribe <- message:
+		case <-s.done:
+		}
+
+		return
+	case MessageCreate:
+		if err = s.limiter.Allow(s.c, "POST /todos"); err == nil {
+			err = s.create(ctx, &todo, message)
+		}
+	case MessageUpdate:
+		if err = s.limiter.Allow(s.c, "PATCH /todos/:ID"); err == nil {
+			err = s.update(ctx, &todo, message)
+		}
+	case MessageDelete:
+		if err = s.limiter.Allow(s.c, "DELETE /todos/:ID"); err == nil {
+			err = s.delete(ctx, &todo, message)
+		}
+	default:
+		err = problem.BadRequest(ErrMessageTypeUnsupported)
+	}
+
+	if err != nil {
+		s.fail(message, err)
+		return
+	}
+
+	ack := Message{Type: MessageAck, ID: message.ID, TodoID: todo.ID}
+	if message.Type != MessageDelete {
+		if ack.Todo, err = json.Marshal(present(s.c, todo)); err != nil {
+			panic(err)
+		}
+	}
+
+	s.enqueue(ack)
+}
+
+func (s *session) create(ctx context.Context, todo *todos.Todo, message Message) error {
+	if len(message.Todo) == 0 {
+		return problem.BadRequest(ErrMessageTodoRequired)
+	}
+
+	if err := json.Unmarshal(message.Todo, todo); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		return problem.Decode(err)
+	}
+
+	todo.UserID = s.userID
+	return s.todos.Create(ctx, todo)
+}
+
+func (s *session) update(ctx context.Context, todo *todos.Todo, message Message) error {
+	if len(message.Todo) == 0 {
+		return problem.BadRequest(ErrMessageTodoRequired)
+	}
+
+	if err := s.load(ctx, todo, message.TodoID); err != nil {
+		return err
+	}
+
+	changes := rel.NewChangeset(todo)
+	if err := json.Unmarshal(message.Todo, todo); err != nil {
+		logger.Warn("decode error", zap.Error(err))
+		return problem.Decode(err)
+	}
+
+	todo.ID, todo.UserID = message.TodoID, s.userID
+	return s.todos.Update(ctx, todo, changes)
+}
+
+func (s *session) delete(ctx context.Context, todo *todos.Todo, message Message) error {
+	if err := s.load(ctx, todo, message.TodoID); err != nil {
+		return err
+	}
+
+	s.todos.Delete(ctx, todo)
+	return nil
+}
+
+// load todo owned by the user.
+func (s *session) load(ctx context.Context, todo *todos.Todo, id uint) error {
+	return s.repository.Find(ctx, todo, where.Eq("id", id).AndEq("user_id", s.userID))
+}
+
+// fail sends err as problem details, only detail of known errors is exposed.
+func (s *session) fail(message Message, err error) {
+	if !problem.Known(err) {
+		logger.Error("internal error", zap.Error(err))
+	}
+
+	problem := problem.New(s.c, err)
+	s.enqueue(Message{Type: MessageError, ID: message.ID, Error: &problem})
+}
+
+// enqueue message, waiting for the writer when the queue is full, eg. while a large backlog is replayed.
+// Connection that doesn't make room within the write wait is closed as too slow.
+func (s *session) enqueue(message Message) {
+	select {
+	case s.send <- message:
+		return
+	case <-s.done:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(wsWriteWait)
+	defer timer.Stop()
+
+	select {
+	case s.send <- message:
+	case <-s.done:
+	case <-timer.C:
+		logger.Warn("websocket queue is full", zap.Int("user_id", s.userID))
+		s.close(websocket.CloseTryAgainLater, "Client is too slow")
+	}
+}
+
+// write queued messages and pings until the session is closed.
+func (s *session) write() {
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case message := <-s.send:
+			s.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := s.conn.WriteJSON(message); err != nil {
+				logger.Warn("websocket write error", zap.Error(err))
+				s.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ping.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				s.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+// pump forwards buffered events of the user once subscribed, until the session or the buffer is closed.
+func (s *session) pump(wait <-chan struct{}) {
+	var (
+		subscribed bool
+		lastID     int64
+	)
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case message := <-s.subscribe:
+			subscribed = true
+			lastID = s.buffer.LastID()
+			if message.LastEventID != nil {
+				lastID = *message.LastEventID
+			}
+
+			s.enqueue(Message{Type: MessageAck, ID: message.ID})
+		case _, open := <-wait:
+			if !open {
+				s.close(websocket.CloseGoingAway, "Server is shutting down")
+				return
+			}
+		}
+
+		if !subscribed {
+			continue
+		}
+
+		entries, ok := s.buffer.Since(lastID)
+		if !ok {
+			s.enqueue(Message{Type: MessageReset})
+		}
+
+		for i := range entries {
+			lastID = entries[i].ID
+			if entries[i].UserID == s.userID {
+				s.enqueue(Message{Type: MessageEvent, EventID: entries[i].ID, Event: &entries[i].Event})
+			}
+		}
+	}
+}
+
+// close session with close code, only the first close takes effect.
+func (s *session) close(code int, text string) {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		if code != websocket.CloseAbnormalClosure {
+			message := websocket.FormatCloseMessage(code, text)
+			s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+		}
+
+		s.conn.Close()
+	})
+}
@@ -0,0 +1,217 @@
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/handler"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// dialWebSocket connects to websocket handler as user 1.
+func dialWebSocket(t *testing.T, handler handler.WebSocket) *websocket.Conn {
+	router := gin.New()
+	handler.Mount(router.Group("/ws", middleware.Auth))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	header := http.Header{}
+	header.Set(middleware.UserIDHeader, "1")
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", header)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	return conn
+}
+
+// readMessage as json.
+func readMessage(t *testing.T, conn *websocket.Conn) string {
+	_, data, err := conn.ReadMessage()
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestWebSocket_Connect(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		response  string
+		mockTodos func(todos *todostest.Service)
+		mockRepo  func(repo *reltest.Repository)
+	}{
+		{
+			name:      "create",
+			message:   `{"type":"create", "id":"c1", "todo":{"title":"Sleep"}}`,
+			response:  `{"type":"ack", "id":"c1", "todo_id":1, "todo":{"id":1, "user_id":1, "title":"Sleep", "notes":"", "order":0, "completed":false, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}}`,
+			mockTodos: todostest.MockCreate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"}, nil),
+		},
+		{
+			name:      "create validation error",
+			message:   `{"type":"create", "id":"c1", "todo":{"title":""}}`,
+			response:  `{"type":"error", "id":"c1", "error":{"type":"/problems/validation", "title":"Unprocessable Entity", "status":422, "detail":"Title can't be blank", "instance":"/ws"}}`,
+			mockTodos: todostest.MockCreate(todos.Todo{}, todos.ErrTodoTitleBlank),
+		},
+		{
+			name:     "create without todo",
+			message:  `{"type":"create", "id":"c1"}`,
+			response: `{"type":"error", "id":"c1", "error":{"type":"/problems/bad-request", "title":"Bad Request", "status":400, "detail":"Todo is required", "instance":"/ws"}}`,
+		},
+		{
+			name:     "update",
+			message:  `{"type":"update", "id":"c2", "todo_id":1, "todo":{"completed":true}}`,
+			response: `{"type":"ack", "id":"c2", "todo_id":1, "todo":{"id":1, "user_id":1, "title":"Sleep", "notes":"", "order":0, "completed":true, "due_at":null, "priority":0, "list":"", "tags":null, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", uint(1)).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+			mockTodos: todostest.MockUpdate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Completed: true}, nil),
+		},
+		{
+			name:     "update not found",
+			message:  `{"type":"update", "id":"c2", "todo_id":2, "todo":{"completed":true}}`,
+			response: `{"type":"error", "id":"c2", "error":{"type":"/problems/not-found", "title":"Not Found", "status":404, "detail":"entity not found", "instance":"/ws"}}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", uint(2)).AndEq("user_id", 1)).NotFound()
+			},
+		},
+		{
+			name:     "delete",
+			message:  `{"type":"delete", "id":"c3", "todo_id":1}`,
+			response: `{"type":"ack", "id":"c3", "todo_id":1}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", uint(1)).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+			mockTodos: todostest.MockDelete(),
+		},
+		{
+			name:     "unsupported type",
+			message:  `{"type":"clear", "id":"c4"}`,
+			response: `{"type":"error", "id":"c4", "error":{"type":"/problems/bad-request", "title":"Bad Request", "status":400, "detail":"Message type is not supported", "instance":"/ws"}}`,
+		},
+		{
+			name:     "malformed",
+			message:  `{"type":`,
+			response: `{"type":"error", "error":{"type":"/problems/bad-request", "title":"Bad Request", "status":400, "detail":"Message is malformed", "instance":"/ws"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				conn       = dialWebSocket(t, handler.NewWebSocket(repository, todos, events.NewBuffer(10), middleware.Limiter{}, 0))
+			)
+
+			todostest.Mock(todos, test.mockTodos)
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(test.message)))
+			assert.JSONEq(t, test.response, readMessage(t, conn))
+
+			repository.AssertExpectations(t)
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebSocket_Connect_rateLimit(t *testing.T) {
+	var (
+		service = &todostest.Service{}
+		limiter = middleware.Limiter{
+			Store:  middleware.NewMemoryStore(0),
+			Limits: middleware.Limits{"POST /todos": {Requests: 1, Period: time.Minute}},
+		}
+		conn = dialWebSocket(t, handler.NewWebSocket(reltest.New(), service, events.NewBuffer(10), limiter, 0))
+	)
+
+	todostest.Mock(service, todostest.MockCreate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"}, nil))
+
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"create", "id":"c1", "todo":{"title":"Sleep"}}`)))
+	assert.Contains(t, readMessage(t, conn), `"type":"ack"`)
+
+	// every create message takes a token of POST /todos, the same as a request.
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"create", "id":"c2", "todo":{"title":"Sleep"}}`)))
+	assert.JSONEq(t, `{"type":"error", "id":"c2", "error":{"type":"/problems/too-many-requests", "title":"Too Many Requests", "status":429, "detail":"Rate limit exceeded, retry later", "instance":"/ws"}}`, readMessage(t, conn))
+
+	service.AssertExpectations(t)
+}
+
+func TestWebSocket_Connect_subscribe(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		at     = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		buffer = events.NewBuffer(10)
+		conn   = dialWebSocket(t, handler.NewWebSocket(reltest.New(), &todostest.Service{}, buffer, middleware.Limiter{}, 0))
+	)
+
+	buffer.Publish(ctx, events.Event{Name: "todo.created", UserID: 1, At: at})
+	buffer.Publish(ctx, events.Event{Name: "todo.updated", UserID: 1, At: at})
+
+	// resumes after the first event.
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"subscribe", "id":"s1", "last_event_id":1}`)))
+	assert.JSONEq(t, `{"type":"ack", "id":"s1"}`, readMessage(t, conn))
+	assert.JSONEq(t, `{"type":"event", "event_id":2, "event":{"name":"todo.updated", "user_id":1, "data":null, "at":"2026-10-19T09:00:00Z"}}`, readMessage(t, conn))
+
+	// changes of other users are not sent.
+	buffer.Publish(ctx, events.Event{Name: "todo.created", UserID: 2, At: at})
+	buffer.Publish(ctx, events.Event{Name: "todos.cleared", UserID: 1, At: at})
+	assert.JSONEq(t, `{"type":"event", "event_id":4, "event":{"name":"todos.cleared", "user_id":1, "data":null, "at":"2026-10-19T09:00:00Z"}}`, readMessage(t, conn))
+
+	// connection is closed when the server is shutting down.
+	buffer.Close()
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func TestWebSocket_Connect_subscribeBacklog(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		at     = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		buffer = events.NewBuffer(10)
+		conn   = dialWebSocket(t, handler.NewWebSocket(reltest.New(), &todostest.Service{}, buffer, middleware.Limiter{}, 2))
+	)
+
+	for i := 0; i < 5; i++ {
+		buffer.Publish(ctx, events.Event{Name: "todo.created", UserID: 1, At: at})
+	}
+
+	// backlog larger than the queue is replayed as the client reads it.
+	assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"subscribe", "id":"s1", "last_event_id":0}`)))
+	assert.JSONEq(t, `{"type":"ack", "id":"s1"}`, readMessage(t, conn))
+	for id := 1; id <= 5; id++ {
+		assert.JSONEq(t, fmt.Sprintf(`{"type":"event", "event_id":%d, "event":{"name":"todo.created", "user_id":1, "data":null, "at":"2026-10-19T09:00:00Z"}}`, id), readMessage(t, conn))
+	}
+}
+
+func TestWebSocket_Connect_unauthorized(t *testing.T) {
+	var (
+		router = gin.New()
+		server = httptest.NewServer(router)
+	)
+
+	defer server.Close()
+
+	handler.NewWebSocket(reltest.New(), &todostest.Service{}, events.NewBuffer(10), middleware.Limiter{}, 0).Mount(router.Group("/ws", middleware.Auth))
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	assert.Equal(t, websocket.ErrBadHandshake, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
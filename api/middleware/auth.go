@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/problem"
+)
+
+const (
+	// UserIDHeader is set by the upstream authentication gateway after the user is authenticated.
+	UserIDHeader = "X-User-ID"
+	// RoleHeader is set by the upstream authentication gateway with the role of the authenticated user.
+	RoleHeader = "X-User-Role"
+	// RoleAdmin is the role of support staff.
+	RoleAdmin = "admin"
+	userIDKey = "authUserIDKey"
+)
+
+var (
+	// ErrUnauthorized error.
+	ErrUnauthorized = errors.New("Unauthorized")
+	// ErrForbidden error.
+	ErrForbidden = errors.New("Forbidden")
+)
+
+// Auth is middleware that authenticates the caller using user id provided by the upstream gateway.
+func Auth(c *gin.Context) {
+	id, err := strconv.Atoi(c.GetHeader(UserIDHeader))
+	if err != nil || id <= 0 {
+		problem.Render(c, problem.Error{Type: problem.TypeUnauthorized, Err: ErrUnauthorized})
+		return
+	}
+
+	c.Set(userIDKey, id)
+	c.Next()
+}
+
+// Admin is middleware that only allows admins, it must be used after Auth.
+func Admin(c *gin.Context) {
+	if c.GetHeader(RoleHeader) != RoleAdmin {
+		problem.Render(c, problem.Error{Type: problem.TypeForbidden, Err: ErrForbidden})
+		return
+	}
+
+	c.Next()
+}
+
+// UserID of the authenticated caller.
+func UserID(c *gin.Context) int {
+	return c.GetInt(userIDKey)
+}
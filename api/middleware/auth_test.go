@@ -0,0 +1,116 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		status   int
+		response string
+	}{
+		{
+			name:     "authenticated",
+			userID:   "1",
+			status:   http.StatusOK,
+			response: `{"user_id":1}`,
+		},
+		{
+			name:     "missing user id",
+			userID:   "",
+			status:   http.StatusUnauthorized,
+			response: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"Unauthorized","instance":"/"}`,
+		},
+		{
+			name:     "invalid user id",
+			userID:   "abc",
+			status:   http.StatusUnauthorized,
+			response: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"Unauthorized","instance":"/"}`,
+		},
+		{
+			name:     "non positive user id",
+			userID:   "0",
+			status:   http.StatusUnauthorized,
+			response: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"Unauthorized","instance":"/"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router = gin.New()
+				req, _ = http.NewRequest("GET", "/", nil)
+				rr     = httptest.NewRecorder()
+			)
+
+			req.Header.Set(middleware.UserIDHeader, test.userID)
+
+			router.Use(middleware.Auth)
+			router.GET("/", func(c *gin.Context) {
+				c.String(http.StatusOK, `{"user_id":`+strconv.Itoa(middleware.UserID(c))+`}`)
+			})
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+		})
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		status   int
+		response string
+	}{
+		{
+			name:     "admin",
+			role:     "admin",
+			status:   http.StatusOK,
+			response: `{"user_id":1}`,
+		},
+		{
+			name:     "missing role",
+			status:   http.StatusForbidden,
+			response: `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"Forbidden","instance":"/"}`,
+		},
+		{
+			name:     "other role",
+			role:     "user",
+			status:   http.StatusForbidden,
+			response: `{"type":"/problems/forbidden","title":"Forbidden","status":403,"detail":"Forbidden","instance":"/"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router = gin.New()
+				req, _ = http.NewRequest("GET", "/", nil)
+				rr     = httptest.NewRecorder()
+			)
+
+			req.Header.Set(middleware.UserIDHeader, "1")
+			req.Header.Set(middleware.RoleHeader, test.role)
+
+			router.Use(middleware.Auth, middleware.Admin)
+			router.GET("/", func(c *gin.Context) {
+				c.String(http.StatusOK, `{"user_id":`+strconv.Itoa(middleware.UserID(c))+`}`)
+			})
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/format"
+	"github.com/go-rel/gin-example/api/problem"
+)
+
+// ListRoutes respond with a list, keyed by method and route without version prefix the same as Limits.
+var ListRoutes = []string{
+	"GET /todos",
+	"GET /score/points",
+	"GET /score/achievements",
+	"GET /rewards",
+	"GET /rewards/redemptions",
+	"GET /challenges",
+	"GET /webhooks",
+	"GET /webhooks/:ID/deliveries",
+}
+
+// Negotiate is middleware that negotiates response format before the request is handled.
+// CSV is only allowed on list routes, it must be used after Version so the route is known without version prefix.
+// Request that doesn't accept any supported format is rejected before it has any effect.
+func Negotiate(lists ...string) gin.HandlerFunc {
+	allowCSV := make(map[string]bool, len(lists))
+	for _, list := range lists {
+		allowCSV[list] = true
+	}
+
+	return func(c *gin.Context) {
+		f, err := format.Negotiate(c)
+		if err == nil && f == format.CSV && !allowCSV[c.Request.Method+" "+route(c)] {
+			err = format.ErrFormatNotList
+		}
+
+		if err != nil {
+			problem.Render(c, err)
+			return
+		}
+
+		format.Set(c, f)
+		c.Next()
+	}
+}
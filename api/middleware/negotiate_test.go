@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/format"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		accept   string
+		status   int
+		response string
+	}{
+		{
+			name:     "default",
+			method:   "GET",
+			path:     "/",
+			status:   http.StatusOK,
+			response: `{"ok":true}`,
+		},
+		{
+			name:     "accept",
+			method:   "GET",
+			path:     "/",
+			accept:   "application/yaml",
+			status:   http.StatusOK,
+			response: "ok: true\n",
+		},
+		{
+			name:     "unsupported accept",
+			method:   "GET",
+			path:     "/",
+			accept:   "application/pdf",
+			status:   http.StatusNotAcceptable,
+			response: `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"Format must be one of json, xml, yaml, msgpack or csv","instance":"/"}`,
+		},
+		{
+			name:     "unsupported query",
+			method:   "GET",
+			path:     "/?format=pdf",
+			status:   http.StatusNotAcceptable,
+			response: `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"Format must be one of json, xml, yaml, msgpack or csv","instance":"/"}`,
+		},
+		{
+			name:     "csv list",
+			method:   "GET",
+			path:     "/v2/todos/?format=csv",
+			status:   http.StatusOK,
+			response: "id\n1\n",
+		},
+		{
+			name:     "csv list accept",
+			method:   "GET",
+			path:     "/v1/todos/",
+			accept:   "text/csv",
+			status:   http.StatusOK,
+			response: "id\n1\n",
+		},
+		{
+			name:     "csv not list",
+			method:   "GET",
+			path:     "/?format=csv",
+			status:   http.StatusNotAcceptable,
+			response: `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"CSV format is only supported for list responses","instance":"/"}`,
+		},
+		{
+			name:     "csv mutation",
+			method:   "POST",
+			path:     "/v2/todos/",
+			accept:   "text/csv",
+			status:   http.StatusNotAcceptable,
+			response: `{"type":"/problems/not-acceptable","title":"Not Acceptable","status":406,"detail":"CSV format is only supported for list responses","instance":"/v2/todos/"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router  = gin.New()
+				req, _  = http.NewRequest(test.method, test.path, nil)
+				rr      = httptest.NewRecorder()
+				handled bool
+			)
+
+			req.Header.Set("Accept", test.accept)
+
+			negotiate := middleware.Negotiate("GET /todos")
+			router.GET("/", negotiate, func(c *gin.Context) {
+				handled = true
+				assert.Nil(t, format.Render(c, http.StatusOK, gin.H{"ok": true}))
+			})
+
+			for _, version := range []string{middleware.VersionV1, middleware.VersionV2} {
+				group := router.Group("/"+version, middleware.Version(version), negotiate)
+				list := func(c *gin.Context) {
+					handled = true
+					assert.Nil(t, format.Render(c, http.StatusOK, []gin.H{{"id": 1}}))
+				}
+
+				group.GET("/todos/", list)
+				group.POST("/todos/", list)
+			}
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.status == http.StatusOK, handled)
+			assert.Equal(t, test.response, rr.Body.String())
+		})
+	}
+}
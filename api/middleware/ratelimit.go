@@ -0,0 +1,192 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/problem"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "middleware")))
+
+	// ErrRateLimited error.
+	ErrRateLimited = errors.New("Rate limit exceeded, retry later")
+	// ErrLimitInvalid config error.
+	ErrLimitInvalid = errors.New("Rate limit requests and period must be positive")
+
+	// DefaultLimits used when no config file is configured.
+	DefaultLimits = Limits{
+		"POST /todos":  {Requests: 60, Period: time.Minute},
+		"GET /healthz": {Requests: 10, Period: time.Second},
+	}
+)
+
+// Limit allows requests within period, which is also the burst allowed at once.
+// Tokens are refilled continuously, so a client waiting period/requests is allowed one more request.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Validate limit.
+func (l Limit) Validate() error {
+	if l.Requests <= 0 || l.Period <= 0 {
+		return ErrLimitInvalid
+	}
+
+	return nil
+}
+
+// UnmarshalJSON accepts period as duration string, eg. "1m".
+func (l *Limit) UnmarshalJSON(data []byte) error {
+	var limit struct {
+		Requests int    `json:"requests"`
+		Period   string `json:"period"`
+	}
+
+	if err := json.Unmarshal(data, &limit); err != nil {
+		return err
+	}
+
+	period, err := time.ParseDuration(limit.Period)
+	if err != nil {
+		return err
+	}
+
+	*l = Limit{Requests: limit.Requests, Period: period}
+	return nil
+}
+
+// rate of refilled tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Limits by route, keyed by method and route without version prefix, eg. "POST /todos" or "GET /todos/:ID".
+// Every version of a route shares the same bucket.
+type Limits map[string]Limit
+
+// Validate limits.
+func (l Limits) Validate() error {
+	for _, limit := range l {
+		if err := limit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadLimits from json file, returns DefaultLimits if path is empty.
+func LoadLimits(path string) (Limits, error) {
+	if path == "" {
+		return DefaultLimits, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits Limits
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return nil, err
+	}
+
+	return limits, limits.Validate()
+}
+
+// RateLimit is middleware that limits requests of a client to configured routes using token bucket in the store.
+// Client is the authenticated user when it's used after Auth, otherwise the client ip.
+// Requests are allowed when the store fails, so an outage of shared store doesn't take the api down.
+func RateLimit(store Store, limits Limits) gin.HandlerFunc {
+	limiter := Limiter{Store: store, Limits: limits}
+
+	return func(c *gin.Context) {
+		limit, result, ok := limiter.take(c, c.Request.Method+" "+route(c))
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		c.Header("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+ceilSeconds(limit.Period))
+
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			problem.Render(c, problem.Error{Type: problem.TypeTooManyRequests, Err: ErrRateLimited})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Limiter limits actions that are not requests the same as requests to a route, eg. todo created by a websocket message
+// takes a token of "POST /todos", so the limit can't be bypassed by sending the message instead.
+// Limiter without limits allows everything.
+type Limiter struct {
+	Store  Store
+	Limits Limits
+}
+
+// Allow action of the client of c that is limited by route key, it returns ErrRateLimited as problem when the limit is exceeded.
+func (l Limiter) Allow(c *gin.Context, key string) error {
+	if _, result, ok := l.take(c, key); ok && !result.Allowed {
+		return problem.Error{Type: problem.TypeTooManyRequests, Err: ErrRateLimited}
+	}
+
+	return nil
+}
+
+// take a token of the route for the client of c, false is returned when the route isn't limited or the store fails.
+func (l Limiter) take(c *gin.Context, key string) (Limit, Result, bool) {
+	limit, ok := l.Limits[key]
+	if !ok {
+		return limit, Result{}, false
+	}
+
+	result, err := l.Store.Take(c, key+" "+client(c), limit)
+	if err != nil {
+		logger.Error("rate limit store error", zap.String("route", key), zap.Error(err))
+		return limit, Result{}, false
+	}
+
+	return limit, result, true
+}
+
+// route of the request without version prefix and trailing slash.
+func route(c *gin.Context) string {
+	path := c.FullPath()
+	if version := APIVersion(c); version != "" {
+		path = strings.TrimPrefix(path, "/"+version)
+	}
+
+	if path = strings.TrimSuffix(path, "/"); path == "" {
+		path = "/"
+	}
+
+	return path
+}
+
+func client(c *gin.Context) string {
+	if id := UserID(c); id != 0 {
+		return "user:" + strconv.Itoa(id)
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
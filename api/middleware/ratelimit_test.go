@@ -0,0 +1,193 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit middleware.Limit) (middleware.Result, error) {
+	return middleware.Result{}, errors.New("store unavailable")
+}
+
+func newRateLimitRouter(store middleware.Store) *gin.Engine {
+	var (
+		router    = gin.New()
+		rateLimit = middleware.RateLimit(store, middleware.Limits{
+			"POST /todos":  {Requests: 2, Period: time.Minute},
+			"GET /healthz": {Requests: 1, Period: time.Minute},
+		})
+		ok = func(c *gin.Context) { c.Status(http.StatusOK) }
+	)
+
+	router.GET("/healthz/", rateLimit, ok)
+	for _, version := range []string{"", middleware.VersionV1, middleware.VersionV2} {
+		group := router.Group("/"+version, middleware.Version(version), middleware.Auth, rateLimit)
+		group.POST("/todos/", ok)
+		group.GET("/todos/", ok)
+	}
+
+	return router
+}
+
+func serve(router *gin.Engine, method string, path string, userID string, ip string) *httptest.ResponseRecorder {
+	var (
+		req, _ = http.NewRequest(method, path, nil)
+		rr     = httptest.NewRecorder()
+	)
+
+	req.RemoteAddr = ip + ":1234"
+	if userID != "" {
+		req.Header.Set(middleware.UserIDHeader, userID)
+	}
+
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimit(t *testing.T) {
+	router := newRateLimitRouter(middleware.NewMemoryStore(0))
+
+	t.Run("allowed", func(t *testing.T) {
+		rr := serve(router, "POST", "/todos/", "1", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", rr.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", rr.Header().Get("RateLimit-Reset"))
+		assert.Equal(t, "2;w=60", rr.Header().Get("RateLimit-Policy"))
+		assert.Empty(t, rr.Header().Get("Retry-After"))
+	})
+
+	t.Run("versions share bucket", func(t *testing.T) {
+		rr := serve(router, "POST", "/v2/todos/", "1", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "0", rr.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		rr := serve(router, "POST", "/v1/todos/", "1", "10.0.0.1")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+		assert.Equal(t, "0", rr.Header().Get("RateLimit-Remaining"))
+		assert.JSONEq(t, `{"type":"/problems/too-many-requests","title":"Too Many Requests","status":429,"detail":"Rate limit exceeded, retry later","instance":"/v1/todos/"}`, rr.Body.String())
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		rr := serve(router, "POST", "/todos/", "2", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("route without limit", func(t *testing.T) {
+		rr := serve(router, "GET", "/todos/", "1", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("RateLimit-Limit"))
+	})
+
+	t.Run("client ip", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve(router, "GET", "/healthz/", "", "10.0.0.1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve(router, "GET", "/healthz/", "", "10.0.0.1").Code)
+		assert.Equal(t, http.StatusOK, serve(router, "GET", "/healthz/", "", "10.0.0.2").Code)
+	})
+}
+
+func TestRateLimit_storeError(t *testing.T) {
+	router := newRateLimitRouter(failingStore{})
+
+	for i := 0; i < 3; i++ {
+		rr := serve(router, "POST", "/todos/", "1", "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	var (
+		router  = gin.New()
+		limiter = middleware.Limiter{
+			Store:  middleware.NewMemoryStore(0),
+			Limits: middleware.Limits{"POST /todos": {Requests: 2, Period: time.Minute}},
+		}
+		group = router.Group("/", middleware.Auth)
+	)
+
+	group.POST("/todos/", middleware.RateLimit(limiter.Store, limiter.Limits), func(c *gin.Context) { c.Status(http.StatusOK) })
+	group.GET("/ws/", func(c *gin.Context) {
+		if err := limiter.Allow(c, c.Query("key")); err != nil {
+			c.Status(http.StatusTooManyRequests)
+			return
+		}
+
+		c.Status(http.StatusOK)
+	})
+
+	// messages share the bucket of the route with requests of the same user.
+	assert.Equal(t, http.StatusOK, serve(router, "POST", "/todos/", "1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, serve(router, "GET", "/ws/?key=POST+%2Ftodos", "1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(router, "GET", "/ws/?key=POST+%2Ftodos", "1", "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, serve(router, "POST", "/todos/", "1", "10.0.0.1").Code)
+
+	// other user has its own bucket.
+	assert.Equal(t, http.StatusOK, serve(router, "GET", "/ws/?key=POST+%2Ftodos", "2", "10.0.0.1").Code)
+
+	// route without limit is always allowed.
+	assert.Equal(t, http.StatusOK, serve(router, "GET", "/ws/?key=DELETE+%2Ftodos%2F:ID", "1", "10.0.0.1").Code)
+
+	// limiter without limits allows everything.
+	assert.Nil(t, middleware.Limiter{}.Allow(&gin.Context{}, "POST /todos"))
+}
+
+func TestLoadLimits(t *testing.T) {
+	var (
+		dir = t.TempDir()
+	)
+
+	t.Run("default", func(t *testing.T) {
+		limits, err := middleware.LoadLimits("")
+		assert.Nil(t, err)
+		assert.Equal(t, middleware.DefaultLimits, limits)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(dir, "limits.json")
+		os.WriteFile(path, []byte(`{"POST /todos": {"requests": 30, "period": "1m"}, "GET /todos/:ID": {"requests": 5, "period": "1s"}}`), 0o600)
+
+		limits, err := middleware.LoadLimits(path)
+		assert.Nil(t, err)
+		assert.Equal(t, middleware.Limits{
+			"POST /todos":    {Requests: 30, Period: time.Minute},
+			"GET /todos/:ID": {Requests: 5, Period: time.Second},
+		}, limits)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		os.WriteFile(path, []byte(`{"POST /todos": {"requests": 0, "period": "1m"}}`), 0o600)
+
+		_, err := middleware.LoadLimits(path)
+		assert.Equal(t, middleware.ErrLimitInvalid, err)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		path := filepath.Join(dir, "period.json")
+		os.WriteFile(path, []byte(`{"POST /todos": {"requests": 1, "period": "minute"}}`), 0o600)
+
+		_, err := middleware.LoadLimits(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := middleware.LoadLimits(filepath.Join(dir, "missing.json"))
+		assert.NotNil(t, err)
+	})
+}
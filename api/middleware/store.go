@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultStoreSize is the number of buckets kept by memory store.
+const DefaultStoreSize = 10000
+
+// Result of taking a token from a bucket.
+type Result struct {
+	Allowed bool
+	// Remaining tokens in the bucket.
+	Remaining int
+	// Reset is the wait until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the wait until the next token is available, zero when allowed.
+	RetryAfter time.Duration
+}
+
+// Store of rate limit buckets, implemented by a shared backend when the api runs as multiple instances.
+type Store interface {
+	// Take a token from bucket of the key, the bucket is refilled at the rate of the limit.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type bucket struct {
+	key    string
+	limit  Limit
+	tokens float64
+	at     time.Time
+}
+
+// refill tokens of the bucket up to now.
+func (b *bucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+now.Sub(b.at).Seconds()*b.limit.rate())
+	b.at = now
+}
+
+// MemoryStore keeps buckets in memory of a single api instance.
+// Buckets that are refilled to full are evicted since they're the same as a new bucket.
+// When the store is full of refilling buckets, the least recently used one is evicted for a new bucket,
+// which only lets its client start over with a full bucket.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	size    int
+	buckets map[string]*list.Element
+	recent  *list.List
+	now     func() time.Time
+}
+
+// Take a token from bucket of the key.
+func (ms *MemoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	now := ms.now()
+	ms.evict(now)
+
+	var b *bucket
+	if elem, ok := ms.buckets[key]; ok {
+		ms.recent.MoveToFront(elem)
+		b = elem.Value.(*bucket)
+		b.limit = limit
+		b.refill(now)
+	} else {
+		if ms.recent.Len() >= ms.size {
+			ms.remove(ms.recent.Back())
+		}
+
+		b = &bucket{key: key, limit: limit, tokens: float64(limit.Requests), at: now}
+		ms.buckets[key] = ms.recent.PushFront(b)
+	}
+
+	var result Result
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / limit.rate())
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((float64(limit.Requests) - b.tokens) / limit.rate())
+
+	return result, nil
+}
+
+// Len returns number of buckets kept in the store.
+func (ms *MemoryStore) Len() int {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	return ms.recent.Len()
+}
+
+// evict buckets that are full by now, starting from the least recently used.
+func (ms *MemoryStore) evict(now time.Time) {
+	for elem := ms.recent.Back(); elem != nil; {
+		b := elem.Value.(*bucket)
+		b.refill(now)
+		if b.tokens < float64(b.limit.Requests) {
+			return
+		}
+
+		prev := elem.Prev()
+		ms.remove(elem)
+		elem = prev
+	}
+}
+
+func (ms *MemoryStore) remove(elem *list.Element) {
+	ms.recent.Remove(elem)
+	delete(ms.buckets, elem.Value.(*bucket).key)
+}
+
+// NewMemoryStore keeping at most size buckets, DefaultStoreSize is used when size is not positive.
+func NewMemoryStore(size int) *MemoryStore {
+	return newMemoryStore(size, time.Now)
+}
+
+func newMemoryStore(size int, now func() time.Time) *MemoryStore {
+	if size <= 0 {
+		size = DefaultStoreSize
+	}
+
+	return &MemoryStore{
+		size:    size,
+		buckets: make(map[string]*list.Element),
+		recent:  list.New(),
+		now:     now,
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
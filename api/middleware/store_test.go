@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		now   = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+		store = newMemoryStore(0, func() time.Time { return now })
+		limit = Limit{Requests: 2, Period: 10 * time.Second}
+	)
+
+	result, err := store.Take(ctx, "a", limit)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 5 * time.Second}, result)
+
+	result, err = store.Take(ctx, "a", limit)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Allowed: true, Remaining: 0, Reset: 10 * time.Second}, result)
+
+	result, err = store.Take(ctx, "a", limit)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Allowed: false, Remaining: 0, Reset: 10 * time.Second, RetryAfter: 5 * time.Second}, result)
+
+	// other key has its own bucket.
+	result, err = store.Take(ctx, "b", limit)
+	assert.Nil(t, err)
+	assert.True(t, result.Allowed)
+
+	// a token is refilled every period/requests.
+	now = now.Add(5 * time.Second)
+	result, err = store.Take(ctx, "a", limit)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Allowed: true, Remaining: 0, Reset: 10 * time.Second}, result)
+}
+
+func TestMemoryStore_evict(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		now   = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+		store = newMemoryStore(2, func() time.Time { return now })
+		limit = Limit{Requests: 2, Period: 10 * time.Second}
+		fast  = Limit{Requests: 1, Period: time.Second}
+	)
+
+	t.Run("least recently used bucket is evicted for new key", func(t *testing.T) {
+		store.Take(ctx, "a", limit)
+		store.Take(ctx, "a", limit)
+		store.Take(ctx, "b", limit)
+
+		// store is full of refilling buckets, so a, the least recently used, is evicted.
+		result, _ := store.Take(ctx, "c", limit)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2, store.Len())
+
+		// a starts over with a full bucket.
+		result, _ = store.Take(ctx, "a", limit)
+		assert.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 5 * time.Second}, result)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("full bucket is evicted", func(t *testing.T) {
+		// a, the least recently used, is refilled to full before c, which is still refilling.
+		now = now.Add(time.Minute)
+		store.Take(ctx, "a", fast)
+		store.Take(ctx, "c", limit)
+		store.Take(ctx, "c", limit)
+
+		now = now.Add(time.Second)
+		store.Take(ctx, "d", limit)
+		assert.Equal(t, 2, store.Len())
+
+		// c isn't evicted, so it's still limited.
+		result, _ := store.Take(ctx, "c", limit)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("full", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		store.Take(ctx, "e", limit)
+		assert.Equal(t, 1, store.Len())
+	})
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/openapi"
+	"github.com/go-rel/gin-example/api/problem"
+)
+
+// Validate returns middleware that rejects requests that don't conform to the OpenAPI spec.
+// It must be used after Auth, so unauthenticated request is rejected before its content is validated.
+func Validate(spec *openapi.Spec) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := spec.Validate(c.Request); err != nil {
+			problem.Render(c, problem.BadRequest(err))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		userID   string
+		status   int
+		response string
+	}{
+		{
+			name:     "valid",
+			method:   "POST",
+			path:     "/todos",
+			body:     `{"title":"Sleep"}`,
+			userID:   "1",
+			status:   http.StatusOK,
+			response: `{"title":"Sleep"}`,
+		},
+		{
+			name:     "invalid body",
+			method:   "POST",
+			path:     "/todos",
+			body:     `{"title":1}`,
+			userID:   "1",
+			status:   http.StatusBadRequest,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"body.title must be a string","instance":"/todos"}`,
+		},
+		{
+			name:     "invalid query",
+			method:   "GET",
+			path:     "/todos?completed=yes",
+			userID:   "1",
+			status:   http.StatusBadRequest,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"query parameter completed must be a boolean","instance":"/todos"}`,
+		},
+		{
+			name:     "unauthenticated",
+			method:   "POST",
+			path:     "/todos",
+			body:     `{"title":1}`,
+			status:   http.StatusUnauthorized,
+			response: `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"Unauthorized","instance":"/todos"}`,
+		},
+	}
+
+	spec, _ := openapi.Load(openapi.Document())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router = gin.New()
+				req, _ = http.NewRequest(test.method, test.path, strings.NewReader(test.body))
+				rr     = httptest.NewRecorder()
+				echo   = func(c *gin.Context) {
+					data, _ := c.GetRawData()
+					c.Data(http.StatusOK, "application/json", data)
+				}
+			)
+
+			req.Header.Set(middleware.UserIDHeader, test.userID)
+
+			router.Use(middleware.Auth, middleware.Validate(spec))
+			router.GET("/todos", echo)
+			router.POST("/todos", echo)
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Api versions.
+const (
+	VersionV1  = "v1"
+	VersionV2  = "v2"
+	versionKey = "apiVersionKey"
+)
+
+// Version is middleware that sets api version of the routes.
+// Unversioned routes are aliases of v1 that use empty version, so urls built for them stay unversioned.
+func Version(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(versionKey, version)
+		c.Next()
+	}
+}
+
+// APIVersion of the request, empty for unversioned routes.
+func APIVersion(c *gin.Context) string {
+	return c.GetString(versionKey)
+}
+
+// Deprecate is middleware that marks routes under prefix as deprecated since at and removed after sunset,
+// the same route under successor prefix is linked as the successor version.
+func Deprecate(prefix string, successor string, at time.Time, sunset time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := strings.TrimPrefix(c.Request.URL.Path, prefix)
+
+		c.Header("Deprecation", "@"+strconv.FormatInt(at.Unix(), 10))
+		c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		c.Writer.Header().Add("Link", "<"+successor+path+">; rel=\"successor-version\"")
+		c.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	var (
+		router = gin.New()
+		req, _ = http.NewRequest("GET", "/v2/", nil)
+		rr     = httptest.NewRecorder()
+	)
+
+	router.Group("/v2", middleware.Version(middleware.VersionV2)).GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, middleware.APIVersion(c))
+	})
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "v2", rr.Body.String())
+}
+
+func TestDeprecate(t *testing.T) {
+	var (
+		router = gin.New()
+		req, _ = http.NewRequest("GET", "/v1/todos/1", nil)
+		rr     = httptest.NewRecorder()
+		at     = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+		sunset = time.Date(2027, 4, 19, 7, 0, 0, 0, time.FixedZone("WIB", 7*60*60))
+	)
+
+	router.Group("/v1", middleware.Deprecate("/v1", "/v2", at, sunset)).GET("/todos/:ID", func(c *gin.Context) {
+		c.Writer.Header().Add("Link", `</v1/todos/2>; rel="next"`)
+		c.Status(http.StatusNoContent)
+	})
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "@1792368000", rr.Header().Get("Deprecation"))
+	assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+	assert.Equal(t, []string{`</v2/todos/1>; rel="successor-version"`, `</v1/todos/2>; rel="next"`}, rr.Header().Values("Link"))
+}
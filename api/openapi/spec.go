@@ -0,0 +1,162 @@
+// Package openapi contains OpenAPI 3 document of the api and validates incoming requests against it.
+// Requests are validated by kin-openapi, this package only matches requests to the documented paths.
+package openapi
+
+import (
+	_ "embed"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// Document returns the OpenAPI document in json.
+func Document() []byte {
+	return document
+}
+
+// Spec is a parsed OpenAPI document.
+type Spec struct {
+	*openapi3.T
+}
+
+// Load parses and validates OpenAPI document.
+func Load(data []byte) (*Spec, error) {
+	loader := openapi3.NewLoader()
+
+	doc, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, err
+	}
+
+	return &Spec{T: doc}, nil
+}
+
+// Find operation of the request path, path parameters are returned by its name.
+// A path without parameter takes precedence over a templated path, eg. /todos/quick over /todos/{ID}.
+func (s *Spec) Find(method string, path string) (*openapi3.Operation, map[string]string, bool) {
+	route, params, ok := s.route(method, path)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return route.Operation, params, true
+}
+
+// Templates of every documented path.
+func (s *Spec) Templates() []string {
+	return s.Paths.InMatchingOrder()
+}
+
+// Methods of the path template that are described by the spec.
+func (s *Spec) Methods(template string) []string {
+	var methods []string
+	if item := s.Paths.Value(template); item != nil {
+		for method := range item.Operations() {
+			methods = append(methods, method)
+		}
+	}
+
+	return methods
+}
+
+// Prefixes of the path template, from the servers it's served from.
+func (s *Spec) Prefixes(template string) []string {
+	if item := s.Paths.Value(template); item != nil {
+		return s.servers(item)
+	}
+
+	return nil
+}
+
+// route of the request path, the documented servers are relative paths so they're matched as path prefixes.
+func (s *Spec) route(method string, path string) (*routers.Route, map[string]string, bool) {
+	var (
+		result   *routers.Route
+		params   map[string]string
+		minCount = -1
+	)
+
+	for template, item := range s.Paths.Map() {
+		operation := item.GetOperation(strings.ToUpper(method))
+		if operation == nil {
+			continue
+		}
+
+		for _, server := range s.servers(item) {
+			relative, ok := strings.CutPrefix(path, server)
+			if !ok || (relative != "" && !strings.HasPrefix(relative, "/")) {
+				continue
+			}
+
+			matched, ok := match(split(template), split(relative))
+			if ok && (minCount < 0 || len(matched) < minCount) {
+				result = &routers.Route{Spec: s.T, Path: template, PathItem: item, Method: strings.ToUpper(method), Operation: operation}
+				params, minCount = matched, len(matched)
+			}
+		}
+	}
+
+	return result, params, result != nil
+}
+
+// servers of the path item as path prefixes without trailing slash, root server is an empty prefix.
+func (s *Spec) servers(item *openapi3.PathItem) []string {
+	servers := item.Servers
+	if len(servers) == 0 {
+		servers = s.Servers
+	}
+
+	if len(servers) == 0 {
+		return []string{""}
+	}
+
+	prefixes := make([]string, len(servers))
+	for i := range servers {
+		prefixes[i] = strings.TrimSuffix(servers[i].URL, "/")
+	}
+
+	return prefixes
+}
+
+// split path into segments, trailing slash is ignored since gin redirects it.
+func split(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+// match path segments to template segments, returns values of templated segments.
+func match(template []string, segments []string) (map[string]string, bool) {
+	if len(template) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i := range template {
+		if name, ok := strings.CutPrefix(template[i], "{"); ok {
+			if segments[i] == "" {
+				return nil, false
+			}
+
+			params[strings.TrimSuffix(name, "}")] = segments[i]
+			continue
+		}
+
+		if template[i] != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
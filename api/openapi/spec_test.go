@@ -0,0 +1,285 @@
+package openapi_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-rel/gin-example/api/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	spec, err := openapi.Load(openapi.Document())
+	assert.Nil(t, err)
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+}
+
+func TestLoad_invalid(t *testing.T) {
+	_, err := openapi.Load([]byte(`{`))
+	assert.NotNil(t, err)
+}
+
+func TestSpec_Find(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		summary string
+		params  map[string]string
+		found   bool
+	}{
+		{
+			name:    "static path",
+			method:  "GET",
+			path:    "/todos",
+			summary: "Search todos",
+			params:  map[string]string{},
+			found:   true,
+		},
+		{
+			name:    "trailing slash",
+			method:  "POST",
+			path:    "/todos/",
+			summary: "Create a todo",
+			params:  map[string]string{},
+			found:   true,
+		},
+		{
+			name:    "templated path",
+			method:  "PATCH",
+			path:    "/todos/1",
+			summary: "Update a todo",
+			params:  map[string]string{"ID": "1"},
+			found:   true,
+		},
+		{
+			name:    "gin path",
+			method:  "GET",
+			path:    "/todos/:ID",
+			summary: "Show a todo",
+			params:  map[string]string{"ID": ":ID"},
+			found:   true,
+		},
+		{
+			name:    "static sibling of templated path",
+			method:  "POST",
+			path:    "/todos/quick",
+			summary: "Create a todo from natural language text",
+			params:  map[string]string{},
+			found:   true,
+		},
+		{
+			name:    "versioned path",
+			method:  "GET",
+			path:    "/v2/todos/1",
+			summary: "Show a todo",
+			params:  map[string]string{"ID": "1"},
+			found:   true,
+		},
+		{
+			name:    "unversioned path",
+			method:  "GET",
+			path:    "/healthz",
+			summary: "Health of the API and its dependencies",
+			params:  map[string]string{},
+			found:   true,
+		},
+		{
+			name:   "unversioned path with version",
+			method: "GET",
+			path:   "/v1/healthz",
+			found:  false,
+		},
+		{
+			name:   "partial version prefix",
+			method: "GET",
+			path:   "/v1todos",
+			found:  false,
+		},
+		{
+			name:   "undocumented method",
+			method: "PUT",
+			path:   "/todos",
+			found:  false,
+		},
+		{
+			name:   "undocumented path",
+			method: "GET",
+			path:   "/unknown",
+			found:  false,
+		},
+	}
+
+	spec, _ := openapi.Load(openapi.Document())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operation, params, found := spec.Find(test.method, test.path)
+			assert.Equal(t, test.found, found)
+			if test.found {
+				assert.Equal(t, test.summary, operation.Summary)
+				assert.Equal(t, test.params, params)
+			}
+		})
+	}
+}
+
+func TestSpec_Find_precedence(t *testing.T) {
+	spec, _ := openapi.Load([]byte(`{
+		"openapi": "3.0.3",
+		"info": { "title": "precedence", "version": "1.0.0" },
+		"paths": {
+			"/todos/{ID}": { "get": { "summary": "templated", "parameters": [{ "name": "ID", "in": "path", "required": true, "schema": { "type": "string" } }], "responses": { "200": { "description": "ok" } } } },
+			"/todos/quick": { "get": { "summary": "static", "responses": { "200": { "description": "ok" } } } }
+		}
+	}`))
+
+	operation, params, found := spec.Find("GET", "/todos/quick")
+	assert.True(t, found)
+	assert.Equal(t, "static", operation.Summary)
+	assert.Empty(t, params)
+
+	operation, params, found = spec.Find("GET", "/todos/1")
+	assert.True(t, found)
+	assert.Equal(t, "templated", operation.Summary)
+	assert.Equal(t, map[string]string{"ID": "1"}, params)
+}
+
+func TestSpec_Prefixes(t *testing.T) {
+	spec, _ := openapi.Load(openapi.Document())
+
+	assert.Equal(t, []string{"/v2", "/v1", ""}, spec.Prefixes("/todos"))
+	assert.Equal(t, []string{""}, spec.Prefixes("/healthz"))
+	assert.Nil(t, spec.Prefixes("/unknown"))
+}
+
+func TestSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    string
+	}{
+		{
+			name:   "valid query",
+			method: "GET",
+			path:   "/score/points?limit=10&aggregate=day&from=2026-01-01T00:00:00Z",
+		},
+		{
+			name:   "invalid integer query",
+			method: "GET",
+			path:   "/score/points?limit=ten",
+			err:    "query parameter limit must be an integer",
+		},
+		{
+			name:   "invalid enum query",
+			method: "GET",
+			path:   "/score/points?aggregate=month",
+			err:    `query parameter aggregate is not one of the allowed values ["day","week"]`,
+		},
+		{
+			name:   "invalid date-time query",
+			method: "GET",
+			path:   "/score/points?from=yesterday",
+			err:    "query parameter from must be a date-time",
+		},
+		{
+			name:   "invalid boolean query",
+			method: "GET",
+			path:   "/todos?completed=yes",
+			err:    "query parameter completed must be a boolean",
+		},
+		{
+			name:   "invalid path",
+			method: "GET",
+			path:   "/todos/abc",
+			err:    "path parameter ID must be an integer",
+		},
+		{
+			name:   "valid body with priority name",
+			method: "POST",
+			path:   "/v2/todos",
+			body:   `{"title":"Sleep","priority":"high"}`,
+		},
+		{
+			name:   "invalid priority name",
+			method: "POST",
+			path:   "/v2/todos",
+			body:   `{"title":"Sleep","priority":"urgent"}`,
+			err:    `body.priority doesn't match any schema from "oneOf"`,
+		},
+		{
+			name:   "valid body",
+			method: "POST",
+			path:   "/todos",
+			body:   `{"title":"Sleep","tags":["home"],"due_at":null}`,
+		},
+		{
+			name:   "invalid body property",
+			method: "POST",
+			path:   "/todos",
+			body:   `{"title":1}`,
+			err:    "body.title must be a string",
+		},
+		{
+			name:   "invalid body item",
+			method: "POST",
+			path:   "/todos",
+			body:   `{"tags":[1]}`,
+			err:    "body.tags[0] must be a string",
+		},
+		{
+			name:   "missing body",
+			method: "POST",
+			path:   "/todos",
+			err:    "body is required but missing",
+		},
+		{
+			name:   "malformed body",
+			method: "POST",
+			path:   "/todos",
+			body:   `{`,
+			err:    "body must be a valid json",
+		},
+		{
+			name:   "undocumented path",
+			method: "GET",
+			path:   "/unknown?limit=ten",
+		},
+	}
+
+	spec, _ := openapi.Load(openapi.Document())
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			req.Header.Set("X-User-ID", "1")
+
+			err := spec.Validate(req)
+			if test.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestSpec_Validate_restoreBody(t *testing.T) {
+	var (
+		spec, _ = openapi.Load(openapi.Document())
+		body    = `{"title":"Sleep"}`
+		req, _  = http.NewRequest("POST", "/todos", strings.NewReader(body))
+	)
+
+	req.Header.Set("X-User-ID", "1")
+
+	assert.Nil(t, spec.Validate(req))
+
+	data, err := io.ReadAll(req.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, body, string(data))
+}
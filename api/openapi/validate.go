@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+var options = &openapi3filter.Options{
+	// defaults are applied by handlers, the request is left as it's sent.
+	SkipSettingDefaults: true,
+	// authentication is done by middleware before the request is validated.
+	AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+}
+
+// Validate parameters and json body of the request.
+// Request to a path that's not described by the spec is not validated.
+// The request body is restored after validation, so it can still be read by handlers.
+func (s *Spec) Validate(req *http.Request) error {
+	route, params, ok := s.route(req.Method, req.URL.Path)
+	if !ok {
+		return nil
+	}
+
+	// body is decoded as json regardless of its content type, the same way handlers bind it.
+	validated := req.Clone(req.Context())
+	validated.Header.Set("Content-Type", "application/json")
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    validated,
+		PathParams: params,
+		Route:      route,
+		Options:    options,
+	}
+
+	err := openapi3filter.ValidateRequest(req.Context(), input)
+	req.Body = validated.Body
+	if err != nil {
+		return describe(err)
+	}
+
+	return nil
+}
+
+// describe validation error by the field that's invalid, eg. "body.title must be a string".
+func describe(err error) error {
+	var (
+		requestErr *openapi3filter.RequestError
+		schemaErr  *openapi3.SchemaError
+		parseErr   *openapi3filter.ParseError
+		field      string
+	)
+
+	if !errors.As(err, &requestErr) {
+		return err
+	}
+
+	switch {
+	case requestErr.Parameter != nil:
+		field = requestErr.Parameter.In + " parameter " + requestErr.Parameter.Name
+	case requestErr.RequestBody != nil:
+		field = "body"
+	default:
+		return err
+	}
+
+	switch {
+	case errors.As(requestErr.Err, &schemaErr):
+		for _, segment := range schemaErr.JSONPointer() {
+			if _, err := strconv.Atoi(segment); err == nil {
+				field += "[" + segment + "]"
+			} else {
+				field += "." + segment
+			}
+		}
+
+		if schemaErr.SchemaField == "format" {
+			return fmt.Errorf("%s must be a %s", field, schemaErr.Schema.Format)
+		}
+
+		return fmt.Errorf("%s %s", field, strings.TrimPrefix(schemaErr.Reason, "value "))
+	case errors.As(requestErr.Err, &parseErr) && requestErr.Parameter != nil:
+		return fmt.Errorf("%s must be %s", field, typeName(requestErr.Parameter.Schema))
+	case errors.As(requestErr.Err, &parseErr):
+		return fmt.Errorf("%s must be a valid json", field)
+	case requestErr.Err != nil:
+		return fmt.Errorf("%s %s", field, strings.TrimPrefix(requestErr.Err.Error(), "value "))
+	}
+
+	return fmt.Errorf("%s %s", field, requestErr.Reason)
+}
+
+// typeName of the schema with its article, eg. "an integer".
+func typeName(schema *openapi3.SchemaRef) string {
+	if schema == nil || schema.Value == nil || schema.Value.Type == nil || len(schema.Value.Type.Slice()) == 0 {
+		return "valid"
+	}
+
+	name := schema.Value.Type.Slice()[0]
+	if strings.ContainsAny(name[:1], "aeiou") {
+		return "an " + name
+	}
+
+	return "a " + name
+}
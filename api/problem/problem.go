@@ -0,0 +1,189 @@
+// Package problem renders errors as RFC 7807 problem details.
+// Domain errors are mapped to problem types, so every handler responds to the same error with the same status.
+package problem
+
+import (
+	"errors"
+	"io"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/format"
+	"github.com/go-rel/gin-example/challenges"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/webhooks"
+	"github.com/go-rel/rel"
+)
+
+// ContentType of problem details.
+const ContentType = "application/problem+json"
+
+// Type of problem, URI is relative to the api and identifies the type.
+type Type struct {
+	URI    string
+	Title  string
+	Status int
+}
+
+var (
+	// TypeBadRequest for malformed request.
+	TypeBadRequest = Type{URI: "/problems/bad-request", Title: "Bad Request", Status: 400}
+	// TypeUnauthorized for unauthenticated request.
+	TypeUnauthorized = Type{URI: "/problems/unauthorized", Title: "Unauthorized", Status: 401}
+	// TypeForbidden for request that's not allowed for the caller.
+	TypeForbidden = Type{URI: "/problems/forbidden", Title: "Forbidden", Status: 403}
+	// TypeNotFound for missing resource.
+	TypeNotFound = Type{URI: "/problems/not-found", Title: "Not Found", Status: 404}
+	// TypeNotAcceptable for response format that's not supported.
+	TypeNotAcceptable = Type{URI: "/problems/not-acceptable", Title: "Not Acceptable", Status: 406}
+	// TypeConflict for request that conflicts with existing resource.
+	TypeConflict = Type{URI: "/problems/conflict", Title: "Conflict", Status: 409}
+	// TypeValidation for well formed request that's rejected by domain rules.
+	TypeValidation = Type{URI: "/problems/validation", Title: "Unprocessable Entity", Status: 422}
+	// TypeTooManyRequests for request that exceeds rate limit of the client.
+	TypeTooManyRequests = Type{URI: "/problems/too-many-requests", Title: "Too Many Requests", Status: 429}
+	// TypeInternal for unexpected error, its detail is never exposed.
+	TypeInternal = Type{URI: "/problems/internal", Title: "Internal Server Error", Status: 500}
+)
+
+// mappings of domain errors, detail defaults to the error message.
+var mappings = []struct {
+	err    error
+	typ    Type
+	detail string
+}{
+	{err: rel.ErrNotFound, typ: TypeNotFound},
+	{err: rel.ErrUniqueConstraint, typ: TypeConflict, detail: "Resource already exists"},
+	{err: rel.ErrForeignKeyConstraint, typ: TypeValidation, detail: "Referenced resource doesn't exist"},
+	{err: rel.ErrCheckConstraint, typ: TypeValidation, detail: "Resource is invalid"},
+	{err: format.ErrFormatUnsupported, typ: TypeNotAcceptable},
+	{err: format.ErrFormatNotList, typ: TypeNotAcceptable},
+	{err: todos.ErrTodoTitleBlank, typ: TypeValidation},
+	{err: todos.ErrTodoPriorityInvalid, typ: TypeValidation},
+	{err: scores.ErrPointAggregateInvalid, typ: TypeBadRequest},
+	{err: scores.ErrPointRangeInvalid, typ: TypeBadRequest},
+	{err: scores.ErrLeaderboardWindowInvalid, typ: TypeBadRequest},
+	{err: scores.ErrPointInsufficient, typ: TypeValidation},
+	{err: scores.ErrPointSpendInvalid, typ: TypeValidation},
+	{err: scores.ErrAdjustmentUserInvalid, typ: TypeValidation},
+	{err: scores.ErrAdjustmentCountInvalid, typ: TypeValidation},
+	{err: scores.ErrAdjustmentReasonBlank, typ: TypeValidation},
+	{err: scores.ErrAdjustmentNegative, typ: TypeValidation},
+	{err: challenges.ErrChallengeEnded, typ: TypeValidation},
+	{err: challenges.ErrChallengeEnrolled, typ: TypeValidation},
+	{err: webhooks.ErrWebhookURLInvalid, typ: TypeValidation},
+	{err: webhooks.ErrWebhookURLBlocked, typ: TypeValidation},
+	{err: webhooks.ErrWebhookSecretShort, typ: TypeValidation},
+	{err: webhooks.ErrWebhookEventUnsupported, typ: TypeValidation},
+}
+
+// Problem details of an error.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Error with explicit problem type, used for errors that don't belong to a domain.
+type Error struct {
+	Type Type
+	Err  error
+}
+
+// Error message, exposed as problem detail.
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap wrapped error.
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// BadRequest wraps err as bad request.
+func BadRequest(err error) error {
+	return Error{Type: TypeBadRequest, Err: err}
+}
+
+// Decode wraps error of decoding request as bad request.
+func Decode(err error) error {
+	switch {
+	case errors.Is(err, io.EOF):
+		err = errors.New("Request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		err = errors.New("Request body is malformed")
+	}
+
+	return BadRequest(err)
+}
+
+// Known returns true if err is mapped to a problem type other than internal error.
+func Known(err error) bool {
+	_, _, ok := Lookup(err)
+	return ok
+}
+
+// New problem details of err for the current request.
+func New(c *gin.Context, err error) Problem {
+	typ, detail, _ := Lookup(err)
+
+	problem := Problem{
+		Type:   typ.URI,
+		Title:  typ.Title,
+		Status: typ.Status,
+		Detail: detail,
+	}
+
+	if c.Request != nil {
+		problem.Instance = c.Request.URL.Path
+		problem.RequestID = requestid.Get(c)
+	}
+
+	return problem
+}
+
+// Render err as problem details and abort the request.
+func Render(c *gin.Context, err error) {
+	problem := New(c, err)
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// Recovery renders internal error problem after a panic, the panic itself is logged by recovery middleware.
+func Recovery(c *gin.Context, _ interface{}) {
+	Render(c, Error{Type: TypeInternal, Err: errors.New("internal error")})
+}
+
+// NotFound renders not found problem for request to unknown route.
+func NotFound(c *gin.Context) {
+	Render(c, Error{Type: TypeNotFound, Err: errors.New("Route not found")})
+}
+
+// Lookup problem type and detail of err, ok is false when err is unexpected and has to be treated as internal error.
+func Lookup(err error) (typ Type, detail string, ok bool) {
+	var e Error
+	if errors.As(err, &e) {
+		if e.Type == TypeInternal {
+			return e.Type, "", false
+		}
+
+		return e.Type, e.Error(), true
+	}
+
+	for _, mapping := range mappings {
+		if errors.Is(err, mapping.err) {
+			if mapping.detail != "" {
+				return mapping.typ, mapping.detail, true
+			}
+
+			return mapping.typ, err.Error(), true
+		}
+	}
+
+	return TypeInternal, "", false
+}
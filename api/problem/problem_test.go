@@ -0,0 +1,130 @@
+package problem_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		status   int
+		response string
+	}{
+		{
+			name:     "domain error",
+			err:      todos.ErrTodoTitleBlank,
+			status:   http.StatusUnprocessableEntity,
+			response: `{"type":"/problems/validation","title":"Unprocessable Entity","status":422,"detail":"Title can't be blank","instance":"/todos","request_id":"abc"}`,
+		},
+		{
+			name:     "wrapped domain error",
+			err:      fmt.Errorf("leaderboard: %w", scores.ErrLeaderboardWindowInvalid),
+			status:   http.StatusBadRequest,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"leaderboard: Window must be one of day, week, month or all","instance":"/todos","request_id":"abc"}`,
+		},
+		{
+			name:     "not found",
+			err:      rel.NotFoundError{},
+			status:   http.StatusNotFound,
+			response: `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"entity not found","instance":"/todos","request_id":"abc"}`,
+		},
+		{
+			name:     "unique constraint",
+			err:      rel.ConstraintError{Key: "todos_title_key", Type: rel.UniqueConstraint, Err: errors.New("Duplicate entry")},
+			status:   http.StatusConflict,
+			response: `{"type":"/problems/conflict","title":"Conflict","status":409,"detail":"Resource already exists","instance":"/todos","request_id":"abc"}`,
+		},
+		{
+			name:     "decode error",
+			err:      problem.Decode(errors.New("invalid character '}'")),
+			status:   http.StatusBadRequest,
+			response: `{"type":"/problems/bad-request","title":"Bad Request","status":400,"detail":"invalid character '}'","instance":"/todos","request_id":"abc"}`,
+		},
+		{
+			name:     "unknown error",
+			err:      errors.New("connection refused"),
+			status:   http.StatusInternalServerError,
+			response: `{"type":"/problems/internal","title":"Internal Server Error","status":500,"instance":"/todos","request_id":"abc"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				router = gin.New()
+				req, _ = http.NewRequest("GET", "/todos?limit=1", nil)
+				rr     = httptest.NewRecorder()
+			)
+
+			req.Header.Set("X-Request-ID", "abc")
+
+			router.Use(requestid.New())
+			router.GET("/todos", func(c *gin.Context) {
+				problem.Render(c, test.err)
+			})
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+		})
+	}
+}
+
+func TestKnown(t *testing.T) {
+	assert.True(t, problem.Known(todos.ErrTodoTitleBlank))
+	assert.True(t, problem.Known(problem.BadRequest(errors.New("invalid"))))
+	assert.False(t, problem.Known(errors.New("connection refused")))
+	assert.False(t, problem.Known(problem.Error{Type: problem.TypeInternal, Err: errors.New("connection refused")}))
+}
+
+func TestDecode(t *testing.T) {
+	assert.EqualError(t, problem.Decode(io.EOF), "Request body is empty")
+	assert.EqualError(t, problem.Decode(io.ErrUnexpectedEOF), "Request body is malformed")
+}
+
+func TestRecovery(t *testing.T) {
+	var (
+		router = gin.New()
+		req, _ = http.NewRequest("GET", "/", nil)
+		rr     = httptest.NewRecorder()
+	)
+
+	router.Use(gin.CustomRecovery(problem.Recovery))
+	router.GET("/", func(c *gin.Context) {
+		panic("system error")
+	})
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, problem.ContentType, rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"/problems/internal","title":"Internal Server Error","status":500,"instance":"/"}`, rr.Body.String())
+}
+
+func TestNotFound(t *testing.T) {
+	var (
+		router = gin.New()
+		req, _ = http.NewRequest("GET", "/unknown", nil)
+		rr     = httptest.NewRecorder()
+	)
+
+	router.NoRoute(problem.NotFound)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.JSONEq(t, `{"type":"/problems/not-found","title":"Not Found","status":404,"detail":"Route not found","instance":"/unknown"}`, rr.Body.String())
+}
@@ -0,0 +1,78 @@
+package challenges
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type award struct {
+	repository rel.Repository
+	scores     scores.Service
+	now        func() time.Time
+}
+
+// complete the enrolment and award the bonus once progress reaches the goal.
+// Both are done in a savepoint, so the enrolment is never completed without its bonus.
+// returns true if the enrolment is completed by this call.
+func (a award) complete(ctx context.Context, challenge Challenge, userID int, now time.Time) (bool, error) {
+	progress, err := a.progress(ctx, challenge, userID)
+	if err != nil || progress < challenge.Goal {
+		return false, err
+	}
+
+	var (
+		completed bool
+		query     = rel.From("enrolments").
+				Where(where.Eq("user_id", userID), where.Eq("challenge_id", challenge.ID), where.Nil("completed_at"))
+	)
+
+	err = a.repository.Transaction(ctx, func(ctx context.Context) error {
+		// only one of concurrent earn completes the enrolment.
+		if updated, err := a.repository.UpdateAny(ctx, query, rel.Set("completed_at", now), rel.Set("updated_at", now)); err != nil || updated == 0 {
+			return err
+		}
+
+		if challenge.Bonus > 0 {
+			source := scores.Source{Type: SourceChallenge, ID: challenge.ID, DedupeKey: fmt.Sprintf("%s:%d", SourceChallenge, challenge.ID)}
+			if err := a.scores.Earn(context.WithValue(ctx, bonusKey{}, true), userID, PointChallengeCompleted, challenge.Bonus, source); err != nil {
+				return err
+			}
+		}
+
+		completed = true
+		return nil
+	})
+
+	return completed && err == nil, err
+}
+
+// progress of a challenge computed from points earned within the challenge's window, may exceed the goal.
+func (a award) progress(ctx context.Context, challenge Challenge, userID int) (int, error) {
+	var (
+		query = rel.From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Where(where.Eq("scores.user_id", userID), where.Gte("points.created_at", challenge.StartAt), where.Lt("points.created_at", challenge.EndAt))
+	)
+
+	switch challenge.Metric {
+	case MetricCompletions:
+		query = query.Where(where.Eq("points.name", PointCompleted), where.Gt("points.count", 0))
+		return a.repository.Aggregate(ctx, query, "count", "points.id")
+	case MetricPoints:
+		// spent and deducted points don't reduce points earned.
+		query = query.Where(where.Ne("points.source_type", SourceChallenge), where.Gt("points.count", 0))
+		return a.repository.Aggregate(ctx, query, "sum", "points.count")
+	case MetricTagCompletions:
+		query = query.JoinOn("todos", "todos.id", "points.source_id").
+			Where(where.Eq("points.name", PointCompleted), where.Gt("points.count", 0), where.Eq("points.source_type", todos.SourceTodo), where.Fragment("FIND_IN_SET(?, todos.tags)", challenge.Tag))
+		return a.repository.Aggregate(ctx, query, "count", "points.id")
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,195 @@
+package challenges
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// Wednesday, 14 October 2026 15:04 UTC, in the middle of the week long challenge.
+	challengeNow   = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+	challengeStart = time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	challengeEnd   = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	weekly         = Challenge{ID: 2, Name: "Weekly 20", Metric: MetricCompletions, Goal: 20, Bonus: 10, StartAt: challengeStart, EndAt: challengeEnd}
+)
+
+func newService(repository *reltest.Repository, scores scores.Service) service {
+	service := New(repository, scores).(service)
+	service.list.now = func() time.Time { return challengeNow }
+	service.enrol.now = func() time.Time { return challengeNow }
+	service.evaluate.now = func() time.Time { return challengeNow }
+	return service
+}
+
+func pointsQuery(userID int) rel.Query {
+	return rel.From("points").
+		JoinOn("scores", "scores.id", "points.score_id").
+		Where(where.Eq("scores.user_id", userID), where.Gte("points.created_at", challengeStart), where.Lt("points.created_at", challengeEnd))
+}
+
+func expectProgress(repository *reltest.Repository, challenge Challenge, userID int) *reltest.MockAggregate {
+	query := pointsQuery(userID)
+
+	switch challenge.Metric {
+	case MetricPoints:
+		return repository.ExpectAggregate(query.Where(where.Ne("points.source_type", SourceChallenge), where.Gt("points.count", 0)), "sum", "points.count")
+	case MetricTagCompletions:
+		query = query.JoinOn("todos", "todos.id", "points.source_id").
+			Where(where.Eq("points.name", PointCompleted), where.Gt("points.count", 0), where.Eq("points.source_type", todos.SourceTodo), where.Fragment("FIND_IN_SET(?, todos.tags)", challenge.Tag))
+		return repository.ExpectAggregate(query, "count", "points.id")
+	default:
+		return repository.ExpectAggregate(query.Where(where.Eq("points.name", PointCompleted), where.Gt("points.count", 0)), "count", "points.id")
+	}
+}
+
+func expectComplete(repository *reltest.Repository, challenge Challenge, userID int, updated int) {
+	query := rel.From("enrolments").Where(where.Eq("user_id", userID), where.Eq("challenge_id", challenge.ID), where.Nil("completed_at"))
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectUpdateAny(query, rel.Set("completed_at", challengeNow), rel.Set("updated_at", challengeNow)).UpdatedCount(updated)
+	})
+}
+
+func TestAward_progress(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge Challenge
+		result    int
+	}{
+		{
+			name:      "completions",
+			challenge: weekly,
+			result:    12,
+		},
+		{
+			name:      "points",
+			challenge: Challenge{ID: 3, Metric: MetricPoints, Goal: 50, StartAt: challengeStart, EndAt: challengeEnd},
+			result:    30,
+		},
+		{
+			name:      "tag completions",
+			challenge: Challenge{ID: 4, Metric: MetricTagCompletions, Tag: "work", Goal: 5, StartAt: challengeStart, EndAt: challengeEnd},
+			result:    3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = newService(repository, &scorestest.Service{})
+			)
+
+			expectProgress(repository, test.challenge, 1).Result(test.result)
+
+			progress, err := service.list.progress(ctx, test.challenge, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, test.result, progress)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAward_complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		progress   int
+		updated    int
+		completed  bool
+		err        error
+		mockRepo   func(repository *reltest.Repository)
+		mockScores func(scores *scorestest.Service)
+	}{
+		{
+			name:     "in progress",
+			progress: 19,
+		},
+		{
+			name:       "completed",
+			progress:   20,
+			updated:    1,
+			completed:  true,
+			mockScores: scorestest.MockEarn(1, PointChallengeCompleted, 10, scores.Source{Type: SourceChallenge, ID: 2, DedupeKey: "challenge:2"}, nil),
+		},
+		{
+			name:       "bonus error",
+			progress:   20,
+			updated:    1,
+			err:        reltest.ErrConnectionClosed,
+			mockScores: scorestest.MockEarn(1, PointChallengeCompleted, 10, scores.Source{Type: SourceChallenge, ID: 2, DedupeKey: "challenge:2"}, reltest.ErrConnectionClosed),
+		},
+		{
+			name:     "completed concurrently",
+			progress: 21,
+			updated:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				scoresSvc  = &scorestest.Service{}
+				service    = newService(repository, scoresSvc)
+			)
+
+			expectProgress(repository, weekly, 1).Result(test.progress)
+			if test.progress >= weekly.Goal {
+				expectComplete(repository, weekly, 1, test.updated)
+			}
+			scorestest.Mock(scoresSvc, test.mockScores)
+
+			completed, err := service.evaluate.complete(ctx, weekly, 1, challengeNow)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.completed, completed)
+			repository.AssertExpectations(t)
+			scoresSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAward_completeWithoutBonus(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = newService(repository, scoresSvc)
+		challenge  = weekly
+	)
+
+	challenge.Bonus = 0
+	expectProgress(repository, challenge, 1).Result(20)
+	expectComplete(repository, challenge, 1, 1)
+
+	completed, err := service.evaluate.complete(ctx, challenge, 1, challengeNow)
+	assert.Nil(t, err)
+	assert.True(t, completed)
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestAward_completeError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = newService(repository, scoresSvc)
+	)
+
+	expectProgress(repository, weekly, 1).ConnectionClosed()
+
+	completed, err := service.evaluate.complete(ctx, weekly, 1, challengeNow)
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	assert.False(t, completed)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,60 @@
+package challenges
+
+import (
+	"time"
+)
+
+// Available challenge metrics.
+const (
+	// MetricCompletions measures number of completed todos.
+	MetricCompletions = "completions"
+	// MetricPoints measures points earned, excluding bonus of challenges.
+	MetricPoints = "points"
+	// MetricTagCompletions measures number of completed todos with the challenge's tag.
+	MetricTagCompletions = "tag_completions"
+)
+
+const (
+	// SourceChallenge is the source type of bonus points awarded by a challenge.
+	SourceChallenge = "challenge"
+	// PointCompleted is the name of points counted as completion.
+	PointCompleted = "todo completed"
+	// PointChallengeCompleted is the name of bonus points awarded by a challenge.
+	PointChallengeCompleted = "challenge completed"
+)
+
+// Challenge is completed when the metric reaches the goal between start and end of the challenge.
+type Challenge struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Metric      string `json:"metric"`
+	// Tag counted by tag_completions metric.
+	Tag   string `json:"tag,omitempty"`
+	Goal  int    `json:"goal"`
+	Bonus int    `json:"bonus"`
+	// StartAt is inclusive, while EndAt is exclusive.
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Started returns true if the challenge already started at now.
+func (c Challenge) Started(now time.Time) bool {
+	return !now.Before(c.StartAt)
+}
+
+// Ended returns true if the challenge already ended at now.
+func (c Challenge) Ended(now time.Time) bool {
+	return !now.Before(c.EndAt)
+}
+
+// Progress of a challenge for a user.
+type Progress struct {
+	Challenge
+	Enrolled bool `json:"enrolled"`
+	// Progress toward the goal, capped at goal.
+	Progress    int        `json:"progress"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
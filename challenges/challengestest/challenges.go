@@ -0,0 +1,39 @@
+package challengestest
+
+import (
+	context "context"
+
+	challenges "github.com/go-rel/gin-example/challenges"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock challenge functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockList util.
+func MockList(result []challenges.Progress, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("List", mock.Anything, userID).
+			Return(result, err)
+	}
+}
+
+// MockEnrol util.
+func MockEnrol(result challenges.Enrolment, challenge challenges.Challenge, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Enrol", mock.Anything, mock.Anything, challenge, userID).
+			Return(func(ctx context.Context, out *challenges.Enrolment, challenge challenges.Challenge, userID int) error {
+				*out = result
+				return err
+			})
+	}
+}
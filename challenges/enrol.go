@@ -0,0 +1,56 @@
+package challenges
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+)
+
+var (
+	// ErrChallengeEnded error.
+	ErrChallengeEnded = errors.New("Challenge already ended")
+	// ErrChallengeEnrolled error.
+	ErrChallengeEnrolled = errors.New("Already enrolled to the challenge")
+)
+
+type enrol struct {
+	award
+}
+
+// Enrol user to a challenge, points earned since the challenge started count toward the goal.
+func (e enrol) Enrol(ctx context.Context, enrolment *Enrolment, challenge Challenge, userID int) error {
+	now := e.now()
+	if challenge.Ended(now) {
+		return ErrChallengeEnded
+	}
+
+	return scores.Retry(ctx, func(ctx context.Context) error {
+		// score changes are published after the enrolment commits.
+		return events.Commit(ctx, func(ctx context.Context) error {
+			return e.repository.Transaction(ctx, func(ctx context.Context) error {
+				*enrolment = Enrolment{UserID: userID, ChallengeID: challenge.ID}
+				if err := e.repository.Insert(ctx, enrolment); err != nil {
+					if errors.Is(err, rel.ErrUniqueConstraint) {
+						return ErrChallengeEnrolled
+					}
+
+					return err
+				}
+
+				if !challenge.Started(now) {
+					return nil
+				}
+
+				completed, err := e.complete(ctx, challenge, userID, now)
+				if completed {
+					enrolment.CompletedAt = &now
+				}
+
+				return err
+			})
+		})
+	})
+}
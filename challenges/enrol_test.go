@@ -0,0 +1,122 @@
+package challenges
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrol(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = newService(repository, scoresSvc)
+		enrolment  Enrolment
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Enrolment{UserID: 1, ChallengeID: 2})
+		expectProgress(repository, weekly, 1).Result(5)
+	})
+
+	assert.Nil(t, service.Enrol(ctx, &enrolment, weekly, 1))
+	assert.Equal(t, 1, enrolment.ID)
+	assert.Nil(t, enrolment.CompletedAt)
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestEnrol_completed(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = newService(repository, scoresSvc)
+		enrolment  Enrolment
+	)
+
+	// points earned before the enrolment counts toward the challenge.
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Enrolment{UserID: 1, ChallengeID: 2})
+		expectProgress(repository, weekly, 1).Result(20)
+		expectComplete(repository, weekly, 1, 1)
+		scorestest.Mock(scoresSvc, scorestest.MockEarn(1, PointChallengeCompleted, 10, scores.Source{Type: SourceChallenge, ID: 2, DedupeKey: "challenge:2"}, nil))
+	})
+
+	assert.Nil(t, service.Enrol(ctx, &enrolment, weekly, 1))
+	assert.Equal(t, &challengeNow, enrolment.CompletedAt)
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestEnrol_notStarted(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = newService(repository, scoresSvc)
+		challenge  = weekly
+		enrolment  Enrolment
+	)
+
+	challenge.StartAt = challengeNow.Add(time.Hour)
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Enrolment{UserID: 1, ChallengeID: 2})
+	})
+
+	assert.Nil(t, service.Enrol(ctx, &enrolment, challenge, 1))
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestEnrol_ended(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository, &scorestest.Service{})
+		challenge  = weekly
+		enrolment  Enrolment
+	)
+
+	challenge.EndAt = challengeNow
+	assert.Equal(t, ErrChallengeEnded, service.Enrol(ctx, &enrolment, challenge, 1))
+	repository.AssertExpectations(t)
+}
+
+func TestEnrol_enrolled(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository, &scorestest.Service{})
+		enrolment  Enrolment
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Enrolment{UserID: 1, ChallengeID: 2}).NotUnique("user_id_challenge_id")
+	})
+
+	assert.Equal(t, ErrChallengeEnrolled, service.Enrol(ctx, &enrolment, weekly, 1))
+	repository.AssertExpectations(t)
+}
+
+func TestEnrol_insertError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository, &scorestest.Service{})
+		enrolment  Enrolment
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Enrol(ctx, &enrolment, weekly, 1))
+	repository.AssertExpectations(t)
+}
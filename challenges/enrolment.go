@@ -0,0 +1,15 @@
+package challenges
+
+import (
+	"time"
+)
+
+// Enrolment of a user to a challenge.
+type Enrolment struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	ChallengeID int        `json:"challenge_id"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,50 @@
+package challenges
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type evaluate struct {
+	award
+}
+
+// bonusKey marks the context of an earned challenge bonus.
+type bonusKey struct{}
+
+// Earned evaluates ongoing challenges the user is enrolled to every time the user earns points.
+// It's a listener of scores, so it runs within the transaction of the earned points,
+// and an error rolls back the earned points together with any challenge completed by them, so the earn can be retried as a whole.
+func (e evaluate) Earned(ctx context.Context, score scores.Score) error {
+	// bonus of a challenge doesn't count toward other challenges.
+	if bonus, _ := ctx.Value(bonusKey{}).(bool); bonus {
+		return nil
+	}
+
+	return e.evaluate(ctx, score.UserID)
+}
+
+func (e evaluate) evaluate(ctx context.Context, userID int) error {
+	var (
+		challenges []Challenge
+		now        = e.now()
+		query      = rel.Select("challenges.*").
+				JoinOn("enrolments", "enrolments.challenge_id", "challenges.id").
+				Where(where.Eq("enrolments.user_id", userID), where.Nil("enrolments.completed_at"), where.Lte("challenges.start_at", now), where.Gt("challenges.end_at", now))
+	)
+
+	if err := e.repository.FindAll(ctx, &challenges, query); err != nil {
+		return err
+	}
+
+	for _, challenge := range challenges {
+		if _, err := e.complete(ctx, challenge, userID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
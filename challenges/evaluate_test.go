@@ -0,0 +1,94 @@
+package challenges
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEarned(t *testing.T) {
+	var (
+		query = rel.Select("challenges.*").
+			JoinOn("enrolments", "enrolments.challenge_id", "challenges.id").
+			Where(where.Eq("enrolments.user_id", 1), where.Nil("enrolments.completed_at"), where.Lte("challenges.start_at", challengeNow), where.Gt("challenges.end_at", challengeNow))
+		points = Challenge{ID: 3, Metric: MetricPoints, Goal: 50, Bonus: 5, StartAt: challengeStart, EndAt: challengeEnd}
+		bonus  = scores.Source{Type: SourceChallenge, ID: 3, DedupeKey: "challenge:3"}
+	)
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		err        error
+		mockRepo   func(repository *reltest.Repository)
+		mockScores func(scores *scorestest.Service)
+	}{
+		{
+			name: "completed",
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(query).Result([]Challenge{weekly, points})
+				expectProgress(repository, weekly, 1).Result(12)
+				expectProgress(repository, points, 1).Result(50)
+				expectComplete(repository, points, 1, 1)
+			},
+			mockScores: scorestest.MockEarn(1, PointChallengeCompleted, 5, bonus, nil),
+		},
+		{
+			name: "no enrolment",
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(query).Result([]Challenge{})
+			},
+		},
+		{
+			// error fails the earn, so it's rolled back and retried as a whole.
+			name: "error",
+			err:  reltest.ErrConnectionClosed,
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(query).ConnectionClosed()
+			},
+		},
+		{
+			name: "bonus error",
+			err:  reltest.ErrConnectionClosed,
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(query).Result([]Challenge{points})
+				expectProgress(repository, points, 1).Result(50)
+				expectComplete(repository, points, 1, 1)
+			},
+			mockScores: scorestest.MockEarn(1, PointChallengeCompleted, 5, bonus, reltest.ErrConnectionClosed),
+		},
+		{
+			name: "challenge bonus",
+			ctx:  context.WithValue(context.TODO(), bonusKey{}, true),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				scoresSvc  = &scorestest.Service{}
+				service    = newService(repository, scoresSvc)
+			)
+
+			if test.ctx != nil {
+				ctx = test.ctx
+			}
+
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+			scorestest.Mock(scoresSvc, test.mockScores)
+
+			assert.Equal(t, test.err, service.Earned(ctx, scores.Score{ID: 1, UserID: 1}))
+			repository.AssertExpectations(t)
+			scoresSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package challenges
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type list struct {
+	award
+}
+
+// List challenges that haven't ended yet with progress of the user.
+func (l list) List(ctx context.Context, userID int) ([]Progress, error) {
+	var (
+		challenges []Challenge
+		enrolments []Enrolment
+		now        = l.now()
+	)
+
+	if err := l.repository.FindAll(ctx, &challenges, where.Gt("end_at", now), rel.SortAsc("end_at"), rel.SortAsc("id")); err != nil {
+		return nil, err
+	}
+
+	if len(challenges) == 0 {
+		return []Progress{}, nil
+	}
+
+	ids := make([]int, len(challenges))
+	for i := range challenges {
+		ids[i] = challenges[i].ID
+	}
+
+	if err := l.repository.FindAll(ctx, &enrolments, where.Eq("user_id", userID), where.InInt("challenge_id", ids)); err != nil {
+		return nil, err
+	}
+
+	enrolled := make(map[int]Enrolment, len(enrolments))
+	for i := range enrolments {
+		enrolled[enrolments[i].ChallengeID] = enrolments[i]
+	}
+
+	result := make([]Progress, len(challenges))
+	for i, challenge := range challenges {
+		result[i].Challenge = challenge
+
+		enrolment, ok := enrolled[challenge.ID]
+		if !ok {
+			continue
+		}
+
+		result[i].Enrolled = true
+		if enrolment.CompletedAt != nil {
+			result[i].Progress = challenge.Goal
+			result[i].CompletedAt = enrolment.CompletedAt
+			continue
+		}
+
+		if !challenge.Started(now) {
+			continue
+		}
+
+		progress, err := l.progress(ctx, challenge, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i].Progress = min(progress, challenge.Goal)
+	}
+
+	return result, nil
+}
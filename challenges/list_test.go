@@ -0,0 +1,79 @@
+package challenges
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = newService(repository, &scorestest.Service{})
+		completedAt = challengeNow.Add(-time.Hour)
+		work        = Challenge{ID: 3, Metric: MetricTagCompletions, Tag: "work", Goal: 5, StartAt: challengeStart, EndAt: challengeEnd}
+		daily       = Challenge{ID: 4, Metric: MetricPoints, Goal: 10, StartAt: challengeStart, EndAt: challengeEnd}
+		upcoming    = Challenge{ID: 5, Metric: MetricCompletions, Goal: 10, StartAt: challengeEnd, EndAt: challengeEnd.AddDate(0, 0, 7)}
+		other       = Challenge{ID: 6, Metric: MetricCompletions, Goal: 10, StartAt: challengeStart, EndAt: challengeEnd}
+	)
+
+	repository.ExpectFindAll(where.Gt("end_at", challengeNow), rel.SortAsc("end_at"), rel.SortAsc("id")).
+		Result([]Challenge{weekly, work, daily, upcoming, other})
+	repository.ExpectFindAll(where.Eq("user_id", 1), where.InInt("challenge_id", []int{2, 3, 4, 5, 6})).Result([]Enrolment{
+		{ID: 1, UserID: 1, ChallengeID: 2},
+		{ID: 2, UserID: 1, ChallengeID: 3},
+		{ID: 3, UserID: 1, ChallengeID: 4, CompletedAt: &completedAt},
+		{ID: 4, UserID: 1, ChallengeID: 5},
+	})
+	expectProgress(repository, weekly, 1).Result(12)
+	expectProgress(repository, work, 1).Result(7)
+
+	result, err := service.List(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []Progress{
+		{Challenge: weekly, Enrolled: true, Progress: 12},
+		{Challenge: work, Enrolled: true, Progress: 5},
+		{Challenge: daily, Enrolled: true, Progress: 10, CompletedAt: &completedAt},
+		{Challenge: upcoming, Enrolled: true},
+		{Challenge: other},
+	}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestList_empty(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository, &scorestest.Service{})
+	)
+
+	repository.ExpectFindAll(where.Gt("end_at", challengeNow), rel.SortAsc("end_at"), rel.SortAsc("id")).Result([]Challenge{})
+
+	result, err := service.List(ctx, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []Progress{}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestList_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository, &scorestest.Service{})
+	)
+
+	repository.ExpectFindAll(where.Gt("end_at", challengeNow), rel.SortAsc("end_at"), rel.SortAsc("id")).Result([]Challenge{weekly})
+	repository.ExpectFindAll(where.Eq("user_id", 1), where.InInt("challenge_id", []int{2})).Result([]Enrolment{{ID: 1, UserID: 1, ChallengeID: 2}})
+	expectProgress(repository, weekly, 1).ConnectionClosed()
+
+	_, err := service.List(ctx, 1)
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,46 @@
+package challenges
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "challenges")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output challengestest --outpkg challengestest
+
+// Service instance for challenge's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	List(ctx context.Context, userID int) ([]Progress, error)
+	Enrol(ctx context.Context, enrolment *Enrolment, challenge Challenge, userID int) error
+	scores.Listener
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	list
+	enrol
+	evaluate
+}
+
+var _ Service = (*service)(nil)
+
+// New Challenges service, bonus of completed challenges is awarded using scores.
+// It should listen to earned points of scores to complete challenges automatically.
+func New(repository rel.Repository, scores scores.Service) Service {
+	award := award{repository: repository, scores: scores, now: time.Now}
+
+	return service{
+		list:     list{award: award},
+		enrol:    enrol{award: award},
+		evaluate: evaluate{award: award},
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,10 +12,17 @@ import (
 	"time"
 
 	"github.com/go-rel/gin-example/api"
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/rpc"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/gin-example/webhooks"
 	"github.com/go-rel/mysql"
 	"github.com/go-rel/rel"
 	_ "github.com/go-sql-driver/mysql"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 var (
@@ -26,16 +34,26 @@ func main() {
 	var (
 		ctx        = context.Background()
 		port       = os.Getenv("PORT")
+		grpcAddr   = net.JoinHostPort(getenv("GRPC_HOST", "127.0.0.1"), getenv("GRPC_PORT", "3001"))
+		grpcToken  = os.Getenv("GRPC_TOKEN")
 		repository = initRepository()
-		handler    = api.New(repository)
+		services   = services.New(repository)
+		handler    = api.New(repository, services)
 		server     = http.Server{
 			Addr:    ":" + port,
 			Handler: handler,
 		}
-		shutdown = make(chan struct{})
+		grpcServer = rpc.New(repository, services, grpcToken, grpcCredentials()...)
+		shutdown   = make(chan struct{})
 	)
 
-	go gracefulShutdown(ctx, &server, shutdown)
+	// close event streams on shutdown, otherwise they're kept open until the client disconnects.
+	server.RegisterOnShutdown(services.Stream.Close)
+
+	monitorDrift(ctx, repository)
+	deliverWebhooks(ctx, services.Webhooks)
+	serveGRPC(grpcServer, grpcAddr, grpcToken)
+	go gracefulShutdown(ctx, &server, grpcServer, shutdown)
 
 	logger.Info("server starting: http://localhost" + server.Addr)
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
@@ -85,7 +103,83 @@ func initRepository() rel.Repository {
 	return repository
 }
 
-func gracefulShutdown(ctx context.Context, server *http.Server, shutdown chan struct{}) {
+// monitorDrift starts logging score drift periodically in background when SCORE_RECONCILE_INTERVAL is set.
+func monitorDrift(ctx context.Context, repository rel.Repository) {
+	interval, err := time.ParseDuration(os.Getenv("SCORE_RECONCILE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	shutdowns = append(shutdowns, func() error {
+		cancel()
+		return nil
+	})
+
+	// reconciliation doesn't depend on scoring config.
+	go scores.MonitorDrift(ctx, scores.New(repository, scores.DefaultConfig, events.Nop{}), interval)
+}
+
+// deliverWebhooks starts attempting due webhook deliveries in background every WEBHOOKS_INTERVAL.
+func deliverWebhooks(ctx context.Context, service webhooks.Service) {
+	// default interval is used when it's empty or invalid.
+	interval, _ := time.ParseDuration(os.Getenv("WEBHOOKS_INTERVAL"))
+
+	ctx, cancel := context.WithCancel(ctx)
+	shutdowns = append(shutdowns, func() error {
+		cancel()
+		return nil
+	})
+
+	go webhooks.Work(ctx, service, interval)
+}
+
+// getenv returns value of the environment variable, or fallback when it's empty.
+func getenv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// grpcCredentials serves grpc over tls when GRPC_TLS_CERT and GRPC_TLS_KEY are set, otherwise it's served in plaintext.
+func grpcCredentials() []grpc.ServerOption {
+	cert, key := os.Getenv("GRPC_TLS_CERT"), os.Getenv("GRPC_TLS_KEY")
+	if cert == "" && key == "" {
+		return nil
+	}
+
+	creds, err := credentials.NewServerTLSFromFile(cert, key)
+	if err != nil {
+		logger.Fatal("grpc tls error", zap.Error(err))
+	}
+
+	return []grpc.ServerOption{grpc.Creds(creds)}
+}
+
+// serveGRPC starts grpc server in background on the address.
+// It's not served without token, since calling services couldn't be authenticated.
+func serveGRPC(server *grpc.Server, addr string, token string) {
+	if token == "" {
+		logger.Warn("grpc server disabled, GRPC_TOKEN is not set")
+		return
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Fatal("grpc listen error", zap.Error(err))
+	}
+
+	logger.Info("grpc server starting: " + addr)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Fatal("grpc server error", zap.Error(err))
+		}
+	}()
+}
+
+func gracefulShutdown(ctx context.Context, server *http.Server, grpcServer *grpc.Server, shutdown chan struct{}) {
 	var (
 		sigint = make(chan os.Signal, 1)
 	)
@@ -100,6 +194,9 @@ func gracefulShutdown(ctx context.Context, server *http.Server, shutdown chan st
 		logger.Fatal("shutdown error", zap.Error(err))
 	}
 
+	// wait for pending grpc calls, before their dependencies are closed.
+	grpcServer.GracefulStop()
+
 	// close any other modules.
 	for i := range shutdowns {
 		shutdowns[i]()
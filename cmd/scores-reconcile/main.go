@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/mysql"
+	"github.com/go-rel/rel"
+	_ "github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "main")))
+	fix       = flag.Bool("fix", false, "correct discrepancies within a transaction")
+)
+
+// scores-reconcile recomputes every score's total point from its points and reports discrepancies.
+// It exits with status 1 when discrepancies are found and not fixed.
+func main() {
+	flag.Parse()
+
+	var (
+		ctx        = context.Background()
+		repository = initRepository()
+		service    = scores.New(repository, scores.DefaultConfig, events.Nop{})
+	)
+
+	discrepancies, err := service.Reconcile(ctx, *fix)
+	if err != nil {
+		logger.Fatal("reconcile error", zap.Error(err))
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "SCORE ID\tUSER ID\tTOTAL POINT\tEXPECTED\tDRIFT")
+	for _, discrepancy := range discrepancies {
+		fmt.Fprintf(writer, "%d\t%d\t%d\t%d\t%d\n",
+			discrepancy.ScoreID, discrepancy.UserID, discrepancy.TotalPoint, discrepancy.Expected, discrepancy.Drift())
+	}
+	writer.Flush()
+
+	switch {
+	case len(discrepancies) == 0:
+		fmt.Println("no discrepancy found")
+	case *fix:
+		fmt.Printf("%d discrepancies fixed\n", len(discrepancies))
+	default:
+		fmt.Printf("%d discrepancies found, run with -fix to correct them\n", len(discrepancies))
+		os.Exit(1)
+	}
+}
+
+func initRepository() rel.Repository {
+	var (
+		dsn = fmt.Sprintf("%s:%s@(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+			os.Getenv("MYSQL_USERNAME"),
+			os.Getenv("MYSQL_PASSWORD"),
+			os.Getenv("MYSQL_HOST"),
+			os.Getenv("MYSQL_PORT"),
+			os.Getenv("MYSQL_DATABASE"))
+	)
+
+	adapter, err := mysql.Open(dsn)
+	if err != nil {
+		logger.Fatal(err.Error(), zap.Error(err))
+	}
+
+	return rel.New(adapter)
+}
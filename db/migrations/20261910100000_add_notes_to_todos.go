@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddNotesToTodos definition
+func MigrateAddNotesToTodos(schema *rel.Schema) {
+	schema.AddColumn("todos", "notes", rel.Text)
+}
+
+// RollbackAddNotesToTodos definition
+func RollbackAddNotesToTodos(schema *rel.Schema) {
+	schema.DropColumn("todos", "notes")
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddPlanningToTodos definition
+func MigrateAddPlanningToTodos(schema *rel.Schema) {
+	schema.AlterTable("todos", func(t *rel.AlterTable) {
+		t.DateTime("due_at")
+		t.SmallInt("priority", rel.Default(0))
+		t.String("list")
+		t.Text("tags")
+	})
+
+	schema.CreateIndex("todos", "due_at", []string{"due_at"})
+}
+
+// RollbackAddPlanningToTodos definition
+func RollbackAddPlanningToTodos(schema *rel.Schema) {
+	schema.DropIndex("todos", "due_at")
+	schema.AlterTable("todos", func(t *rel.AlterTable) {
+		t.DropColumn("due_at")
+		t.DropColumn("priority")
+		t.DropColumn("list")
+		t.DropColumn("tags")
+	})
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddUserIDToScores definition
+func MigrateAddUserIDToScores(schema *rel.Schema) {
+	schema.AddColumn("scores", "user_id", rel.Int, rel.Unsigned(true), rel.Required(true))
+	schema.CreateUniqueIndex("scores", "user_id", []string{"user_id"})
+}
+
+// RollbackAddUserIDToScores definition
+func RollbackAddUserIDToScores(schema *rel.Schema) {
+	schema.DropIndex("scores", "user_id")
+	schema.DropColumn("scores", "user_id")
+}
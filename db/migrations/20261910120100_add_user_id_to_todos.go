@@ -0,0 +1,17 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddUserIDToTodos definition
+func MigrateAddUserIDToTodos(schema *rel.Schema) {
+	schema.AddColumn("todos", "user_id", rel.Int, rel.Unsigned(true), rel.Required(true))
+	schema.CreateIndex("todos", "user_id", []string{"user_id"})
+}
+
+// RollbackAddUserIDToTodos definition
+func RollbackAddUserIDToTodos(schema *rel.Schema) {
+	schema.DropIndex("todos", "user_id")
+	schema.DropColumn("todos", "user_id")
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreatePointsScoreIDCreatedAtIndex definition
+func MigrateCreatePointsScoreIDCreatedAtIndex(schema *rel.Schema) {
+	schema.CreateIndex("points", "score_id_created_at", []string{"score_id", "created_at"})
+}
+
+// RollbackCreatePointsScoreIDCreatedAtIndex definition
+func RollbackCreatePointsScoreIDCreatedAtIndex(schema *rel.Schema) {
+	schema.DropIndex("points", "score_id_created_at")
+}
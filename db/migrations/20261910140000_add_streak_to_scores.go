@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddStreakToScores definition
+func MigrateAddStreakToScores(schema *rel.Schema) {
+	schema.AlterTable("scores", func(t *rel.AlterTable) {
+		t.Int("current_streak", rel.Default(0))
+		t.Int("longest_streak", rel.Default(0))
+		t.String("streak_on", rel.Limit(10))
+	})
+}
+
+// RollbackAddStreakToScores definition
+func RollbackAddStreakToScores(schema *rel.Schema) {
+	schema.AlterTable("scores", func(t *rel.AlterTable) {
+		t.DropColumn("current_streak")
+		t.DropColumn("longest_streak")
+		t.DropColumn("streak_on")
+	})
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateUnlockedAchievements definition
+func MigrateCreateUnlockedAchievements(schema *rel.Schema) {
+	schema.CreateTable("unlocked_achievements", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("user_id", rel.Unsigned(true), rel.Required(true))
+		t.String("code", rel.Required(true))
+	})
+
+	// each achievement is only granted once per user.
+	schema.CreateUniqueIndex("unlocked_achievements", "user_id_code", []string{"user_id", "code"})
+}
+
+// RollbackCreateUnlockedAchievements definition
+func RollbackCreateUnlockedAchievements(schema *rel.Schema) {
+	schema.DropTable("unlocked_achievements")
+}
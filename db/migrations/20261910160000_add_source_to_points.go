@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddSourceToPoints definition
+func MigrateAddSourceToPoints(schema *rel.Schema) {
+	schema.AlterTable("points", func(t *rel.AlterTable) {
+		t.String("source_type", rel.Limit(32), rel.Default(""))
+		t.Int("source_id", rel.Unsigned(true), rel.Default(0))
+		t.String("dedupe_key")
+	})
+
+	schema.CreateIndex("points", "source_type_source_id", []string{"source_type", "source_id"})
+	// null dedupe key can be earned repeatedly.
+	schema.CreateUniqueIndex("points", "score_id_dedupe_key", []string{"score_id", "dedupe_key"})
+}
+
+// RollbackAddSourceToPoints definition
+func RollbackAddSourceToPoints(schema *rel.Schema) {
+	schema.DropIndex("points", "score_id_dedupe_key")
+	schema.DropIndex("points", "source_type_source_id")
+	schema.AlterTable("points", func(t *rel.AlterTable) {
+		t.DropColumn("source_type")
+		t.DropColumn("source_id")
+		t.DropColumn("dedupe_key")
+	})
+}
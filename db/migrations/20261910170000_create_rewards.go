@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateRewards definition
+func MigrateCreateRewards(schema *rel.Schema) {
+	schema.CreateTable("rewards", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.String("name", rel.Required(true))
+		t.Text("description")
+		t.Int("cost", rel.Unsigned(true), rel.Required(true))
+	})
+}
+
+// RollbackCreateRewards definition
+func RollbackCreateRewards(schema *rel.Schema) {
+	schema.DropTable("rewards")
+}
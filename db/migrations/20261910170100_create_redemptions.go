@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateRedemptions definition
+func MigrateCreateRedemptions(schema *rel.Schema) {
+	schema.CreateTable("redemptions", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("user_id", rel.Unsigned(true), rel.Required(true))
+		t.Int("reward_id", rel.Unsigned(true), rel.Required(true))
+		t.String("name", rel.Required(true))
+		t.Int("cost", rel.Required(true))
+
+		t.ForeignKey("reward_id", "rewards", "id")
+	})
+
+	schema.CreateIndex("redemptions", "user_id", []string{"user_id"})
+}
+
+// RollbackCreateRedemptions definition
+func RollbackCreateRedemptions(schema *rel.Schema) {
+	schema.DropTable("redemptions")
+}
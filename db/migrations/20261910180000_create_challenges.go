@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateChallenges definition
+func MigrateCreateChallenges(schema *rel.Schema) {
+	schema.CreateTable("challenges", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.String("name", rel.Required(true))
+		t.Text("description")
+		t.String("metric", rel.Limit(32), rel.Required(true))
+		t.String("tag", rel.Default(""))
+		t.Int("goal", rel.Unsigned(true), rel.Required(true))
+		t.Int("bonus", rel.Unsigned(true), rel.Default(0))
+		t.DateTime("start_at", rel.Required(true))
+		t.DateTime("end_at", rel.Required(true))
+	})
+
+	schema.CreateIndex("challenges", "end_at", []string{"end_at"})
+}
+
+// RollbackCreateChallenges definition
+func RollbackCreateChallenges(schema *rel.Schema) {
+	schema.DropTable("challenges")
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateEnrolments definition
+func MigrateCreateEnrolments(schema *rel.Schema) {
+	schema.CreateTable("enrolments", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("user_id", rel.Unsigned(true), rel.Required(true))
+		t.Int("challenge_id", rel.Unsigned(true), rel.Required(true))
+		t.DateTime("completed_at")
+
+		t.ForeignKey("challenge_id", "challenges", "id")
+	})
+
+	// each user enrols to a challenge at most once.
+	schema.CreateUniqueIndex("enrolments", "user_id_challenge_id", []string{"user_id", "challenge_id"})
+}
+
+// RollbackCreateEnrolments definition
+func RollbackCreateEnrolments(schema *rel.Schema) {
+	schema.DropTable("enrolments")
+}
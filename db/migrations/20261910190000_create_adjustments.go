@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateAdjustments definition
+func MigrateCreateAdjustments(schema *rel.Schema) {
+	schema.CreateTable("adjustments", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("user_id", rel.Unsigned(true), rel.Required(true))
+		t.Int("count", rel.Required(true))
+		t.Text("reason", rel.Required(true))
+		t.Int("adjusted_by", rel.Unsigned(true), rel.Required(true))
+		t.Bool("forced", rel.Default(false))
+	})
+
+	schema.CreateIndex("adjustments", "user_id", []string{"user_id"})
+}
+
+// RollbackCreateAdjustments definition
+func RollbackCreateAdjustments(schema *rel.Schema) {
+	schema.DropTable("adjustments")
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateWebhooks definition
+func MigrateCreateWebhooks(schema *rel.Schema) {
+	schema.CreateTable("webhooks", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("user_id", rel.Unsigned(true), rel.Required(true))
+		t.Text("url", rel.Required(true))
+		t.String("secret", rel.Required(true))
+		t.Text("events")
+		t.Bool("enabled", rel.Default(true))
+		t.Int("failures", rel.Default(0))
+		t.DateTime("disabled_at")
+	})
+
+	schema.CreateIndex("webhooks", "user_id_enabled", []string{"user_id", "enabled"})
+}
+
+// RollbackCreateWebhooks definition
+func RollbackCreateWebhooks(schema *rel.Schema) {
+	schema.DropTable("webhooks")
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateDeliveries definition
+func MigrateCreateDeliveries(schema *rel.Schema) {
+	schema.CreateTable("deliveries", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Int("webhook_id", rel.Unsigned(true), rel.Required(true))
+		t.String("event", rel.Required(true))
+		t.Text("payload", rel.Required(true))
+		t.String("status", rel.Required(true))
+		t.Int("attempts", rel.Default(0))
+		t.DateTime("next_attempt_at")
+		t.Int("response_status", rel.Default(0))
+		t.Text("error")
+
+		// delivery log is deleted together with its webhook.
+		t.ForeignKey("webhook_id", "webhooks", "id", rel.OnDelete("CASCADE"))
+	})
+
+	// due deliveries are polled by status and next attempt.
+	schema.CreateIndex("deliveries", "status_next_attempt_at", []string{"status", "next_attempt_at"})
+}
+
+// RollbackCreateDeliveries definition
+func RollbackCreateDeliveries(schema *rel.Schema) {
+	schema.DropTable("deliveries")
+}
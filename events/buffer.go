@@ -0,0 +1,123 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBufferSize is the number of events kept by buffer when size is not specified.
+const DefaultBufferSize = 1000
+
+// Entry is an event kept in buffer with its sequential id.
+type Entry struct {
+	ID int64
+	Event
+}
+
+// Buffer keeps the latest events in memory with sequential ids, so subscribers can resume from the last event they received.
+// Ids start over when the buffer is created again, eg. when the api restarts.
+type Buffer struct {
+	mutex    sync.Mutex
+	size     int
+	entries  []Entry
+	lastID   int64
+	waiters  map[int]chan struct{}
+	nextWait int
+	closed   bool
+}
+
+var _ Publisher = (*Buffer)(nil)
+
+// Publish appends event to buffer, dropping the oldest event when it's full, and wakes up every waiter.
+func (b *Buffer) Publish(ctx context.Context, event Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lastID++
+	if len(b.entries) == b.size {
+		b.entries = b.entries[1:]
+	}
+	b.entries = append(b.entries, Entry{ID: b.lastID, Event: event})
+
+	for _, waiter := range b.waiters {
+		select {
+		case waiter <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Since returns buffered entries after id.
+// It returns false together with every buffered entry when some entries after id were dropped, or id is unknown to the buffer.
+func (b *Buffer) Since(id int64) ([]Entry, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	firstID := b.lastID - int64(len(b.entries)) + 1
+	if id < firstID-1 || id > b.lastID {
+		return append([]Entry(nil), b.entries...), false
+	}
+
+	return append([]Entry(nil), b.entries[id-firstID+1:]...), true
+}
+
+// LastID is the id of the latest published event, zero when nothing is published yet.
+func (b *Buffer) LastID() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.lastID
+}
+
+// Wait returns channel that receives whenever events are published and function to stop waiting.
+// The channel is closed when buffer is closed.
+func (b *Buffer) Wait() (<-chan struct{}, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	waiter := make(chan struct{}, 1)
+	if b.closed {
+		close(waiter)
+		return waiter, func() {}
+	}
+
+	id := b.nextWait
+	b.nextWait++
+	b.waiters[id] = waiter
+
+	return waiter, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		delete(b.waiters, id)
+	}
+}
+
+// Close buffer, every waiter is released.
+func (b *Buffer) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.closed = true
+	for id, waiter := range b.waiters {
+		close(waiter)
+		delete(b.waiters, id)
+	}
+}
+
+// NewBuffer keeping at most size events, DefaultBufferSize is used when size is not positive.
+func NewBuffer(size int) *Buffer {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+
+	return &Buffer{
+		size:    size,
+		entries: make([]Entry, 0, size),
+		waiters: make(map[int]chan struct{}),
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer_Since(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		buffer = NewBuffer(2)
+	)
+
+	entries, ok := buffer.Since(0)
+	assert.True(t, ok)
+	assert.Empty(t, entries)
+
+	buffer.Publish(ctx, Event{Name: "todo.created"})
+	buffer.Publish(ctx, Event{Name: "todo.updated"})
+	buffer.Publish(ctx, Event{Name: "todo.deleted"})
+	assert.Equal(t, int64(3), buffer.LastID())
+
+	tests := []struct {
+		name    string
+		id      int64
+		entries []Entry
+		ok      bool
+	}{
+		{
+			name:    "resumed",
+			id:      2,
+			entries: []Entry{{ID: 3, Event: Event{Name: "todo.deleted"}}},
+			ok:      true,
+		},
+		{
+			name: "up to date",
+			id:   3,
+			ok:   true,
+		},
+		{
+			name:    "dropped",
+			id:      0,
+			entries: []Entry{{ID: 2, Event: Event{Name: "todo.updated"}}, {ID: 3, Event: Event{Name: "todo.deleted"}}},
+		},
+		{
+			name:    "unknown",
+			id:      10,
+			entries: []Entry{{ID: 2, Event: Event{Name: "todo.updated"}}, {ID: 3, Event: Event{Name: "todo.deleted"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries, ok := buffer.Since(test.id)
+			assert.Equal(t, test.entries, entries)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
+
+func TestBuffer_Wait(t *testing.T) {
+	var (
+		ctx          = context.TODO()
+		buffer       = NewBuffer(0)
+		wait, cancel = buffer.Wait()
+	)
+
+	defer cancel()
+
+	// waiter isn't blocking publisher when it's already notified.
+	buffer.Publish(ctx, Event{Name: "todo.created"})
+	buffer.Publish(ctx, Event{Name: "todo.updated"})
+
+	_, open := <-wait
+	assert.True(t, open)
+
+	buffer.Close()
+	_, open = <-wait
+	assert.False(t, open)
+
+	wait, _ = buffer.Wait()
+	_, open = <-wait
+	assert.False(t, open)
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Bus is an in-memory publisher that synchronously calls every subscribed handler.
+type Bus struct {
+	mutex    sync.RWMutex
+	handlers map[int]Handler
+	nextID   int
+	now      func() time.Time
+}
+
+var _ Publisher = (*Bus)(nil)
+
+// Publish event to all subscribers, the time of the event is set if empty.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.At.IsZero() {
+		event.At = b.now()
+	}
+
+	b.mutex.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		handler(ctx, event)
+	}
+}
+
+// Subscribe handler to all events, returns function to unsubscribe.
+func (b *Bus) Subscribe(handler Handler) func() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		delete(b.handlers, id)
+	}
+}
+
+// NewBus event bus.
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[int]Handler),
+		now:      time.Now,
+	}
+}
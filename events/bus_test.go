@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		now      = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+		bus      = NewBus()
+		received []Event
+	)
+
+	bus.now = func() time.Time { return now }
+
+	unsubscribe := bus.Subscribe(func(ctx context.Context, event Event) {
+		received = append(received, event)
+	})
+
+	bus.Publish(ctx, Event{Name: "achievement.unlocked", UserID: 1})
+	unsubscribe()
+	bus.Publish(ctx, Event{Name: "achievement.unlocked", UserID: 2})
+
+	assert.Equal(t, []Event{{Name: "achievement.unlocked", UserID: 1, At: now}}, received)
+}
+
+func TestNop(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Nop{}.Publish(context.TODO(), Event{Name: "achievement.unlocked"})
+	})
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+)
+
+type commitKey struct{}
+
+type deferred struct {
+	publisher Publisher
+	event     Event
+}
+
+type pending struct {
+	events []deferred
+}
+
+// Commit runs fn, usually a transaction, and publishes events deferred by AfterCommit within fn only when fn succeeds.
+// Commit nested within another commit defers its events to the outermost commit, and discards them when fn fails.
+// When fn is retried, Commit should be called within the retry so events of a failed attempt are discarded.
+func Commit(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p, ok := ctx.Value(commitKey{}).(*pending); ok {
+		n := len(p.events)
+		if err := fn(ctx); err != nil {
+			p.events = p.events[:n]
+			return err
+		}
+
+		return nil
+	}
+
+	p := &pending{}
+	if err := fn(context.WithValue(ctx, commitKey{}, p)); err != nil {
+		return err
+	}
+
+	for _, d := range p.events {
+		d.publisher.Publish(ctx, d.event)
+	}
+
+	return nil
+}
+
+// AfterCommit publishes event when the outermost commit of ctx succeeds, or immediately when ctx is not within a commit.
+func AfterCommit(ctx context.Context, publisher Publisher, event Event) {
+	if p, ok := ctx.Value(commitKey{}).(*pending); ok {
+		p.events = append(p.events, deferred{publisher: publisher, event: event})
+		return
+	}
+
+	publisher.Publish(ctx, event)
+}
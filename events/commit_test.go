@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommit(t *testing.T) {
+	var (
+		ctx       = context.TODO()
+		bus       = NewBus()
+		published []string
+		err       = errors.New("deadlock")
+	)
+
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		published = append(published, event.Name)
+	})
+
+	assert.Nil(t, Commit(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, bus, Event{Name: "todo.created"})
+
+		// events of failed nested commit are discarded, even though the outer commit succeeds.
+		assert.Equal(t, err, Commit(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, bus, Event{Name: "score.changed"})
+			return err
+		}))
+
+		assert.Nil(t, Commit(ctx, func(ctx context.Context) error {
+			AfterCommit(ctx, bus, Event{Name: "todo.updated"})
+			return nil
+		}))
+
+		assert.Empty(t, published)
+		return nil
+	}))
+
+	assert.Equal(t, []string{"todo.created", "todo.updated"}, published)
+}
+
+func TestCommit_failed(t *testing.T) {
+	var (
+		ctx       = context.TODO()
+		bus       = NewBus()
+		published []string
+		err       = errors.New("deadlock")
+	)
+
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		published = append(published, event.Name)
+	})
+
+	assert.Equal(t, err, Commit(ctx, func(ctx context.Context) error {
+		AfterCommit(ctx, bus, Event{Name: "todo.created"})
+		return err
+	}))
+
+	assert.Empty(t, published)
+}
+
+func TestAfterCommit_withoutCommit(t *testing.T) {
+	var (
+		ctx       = context.TODO()
+		bus       = NewBus()
+		published []string
+	)
+
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		published = append(published, event.Name)
+	})
+
+	AfterCommit(ctx, bus, Event{Name: "todo.deleted"})
+	assert.Equal(t, []string{"todo.deleted"}, published)
+}
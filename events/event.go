@@ -0,0 +1,28 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Event is a notification of something that already happened within a domain.
+type Event struct {
+	Name   string      `json:"name"`
+	UserID int         `json:"user_id"`
+	Data   interface{} `json:"data"`
+	At     time.Time   `json:"at"`
+}
+
+// Publisher publishes event to subscribers.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Handler handles published event.
+type Handler func(ctx context.Context, event Event)
+
+// Nop publisher that discards every event.
+type Nop struct{}
+
+// Publish discards event.
+func (Nop) Publish(ctx context.Context, event Event) {}
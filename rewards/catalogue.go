@@ -0,0 +1,15 @@
+package rewards
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+)
+
+type catalogue struct {
+	repository rel.Repository
+}
+
+func (c catalogue) Catalogue(ctx context.Context, rewards *[]Reward) error {
+	return c.repository.FindAll(ctx, rewards, rel.SortAsc("cost"), rel.SortAsc("id"))
+}
@@ -0,0 +1,44 @@
+package rewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogue(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, &scorestest.Service{})
+		result     []Reward
+		rewards    = []Reward{{ID: 1, Name: "Coffee", Cost: 10}, {ID: 2, Name: "Day off", Cost: 500}}
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("cost"), rel.SortAsc("id")).Result(rewards)
+
+	assert.Nil(t, service.Catalogue(ctx, &result))
+	assert.Equal(t, rewards, result)
+	repository.AssertExpectations(t)
+}
+
+func TestHistory(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = New(repository, &scorestest.Service{})
+		result      []Redemption
+		redemptions = []Redemption{{ID: 2, UserID: 1, RewardID: 1, Name: "Coffee", Cost: 10}}
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1), rel.SortDesc("id")).Result(redemptions)
+
+	assert.Nil(t, service.History(ctx, &result, 1))
+	assert.Equal(t, redemptions, result)
+	repository.AssertExpectations(t)
+}
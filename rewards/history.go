@@ -0,0 +1,16 @@
+package rewards
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type history struct {
+	repository rel.Repository
+}
+
+func (h history) History(ctx context.Context, redemptions *[]Redemption, userID int) error {
+	return h.repository.FindAll(ctx, redemptions, where.Eq("user_id", userID), rel.SortDesc("id"))
+}
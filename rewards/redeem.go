@@ -0,0 +1,36 @@
+package rewards
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+)
+
+type redeem struct {
+	repository rel.Repository
+	scores     scores.Service
+}
+
+// Redeem reward by spending user's points, the redemption is rolled back when user has insufficient points.
+// Free reward is redeemed without spending points.
+func (r redeem) Redeem(ctx context.Context, redemption *Redemption, reward Reward, userID int) error {
+	return scores.Retry(ctx, func(ctx context.Context) error {
+		// score changes are published after the redemption commits.
+		return events.Commit(ctx, func(ctx context.Context) error {
+			return r.repository.Transaction(ctx, func(ctx context.Context) error {
+				*redemption = Redemption{UserID: userID, RewardID: reward.ID, Name: reward.Name, Cost: reward.Cost}
+				if err := r.repository.Insert(ctx, redemption); err != nil {
+					return err
+				}
+
+				if reward.Cost == 0 {
+					return nil
+				}
+
+				return r.scores.Spend(ctx, userID, PointRedeemed, reward.Cost, scores.Source{Type: SourceRedemption, ID: redemption.ID})
+			})
+		})
+	})
+}
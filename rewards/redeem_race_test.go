@@ -0,0 +1,85 @@
+package rewards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/mysql"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRedeem_race redeems simultaneously against a migrated mysql database, balance must never be overdrawn:
+//
+//	export $(cat .env | grep -v ^\# | xargs) && go test ./rewards -run TestRedeem_race
+func TestRedeem_race(t *testing.T) {
+	if os.Getenv("MYSQL_HOST") == "" {
+		t.Skip("MYSQL_HOST is not set")
+	}
+
+	adapter, err := mysql.Open(fmt.Sprintf("%s:%s@(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		os.Getenv("MYSQL_USERNAME"),
+		os.Getenv("MYSQL_PASSWORD"),
+		os.Getenv("MYSQL_HOST"),
+		os.Getenv("MYSQL_PORT"),
+		os.Getenv("MYSQL_DATABASE")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adapter.Close()
+
+	var (
+		ctx        = context.Background()
+		repository = rel.New(adapter)
+		service    = New(repository, scores.New(repository, scores.DefaultConfig, events.Nop{}))
+		userID     = 2000000
+		score      = scores.Score{UserID: userID, TotalPoint: 25}
+		reward     = Reward{Name: "Race", Cost: 10}
+		attempts   = 10
+		redeemed   = 0
+		mutex      sync.Mutex
+		wg         sync.WaitGroup
+	)
+
+	repository.MustInsert(ctx, &score)
+	repository.MustInsert(ctx, &reward)
+	t.Cleanup(func() {
+		repository.MustDeleteAny(ctx, rel.From("points").Where(where.Eq("score_id", score.ID)))
+		repository.MustDeleteAny(ctx, rel.From("redemptions").Where(where.Eq("user_id", userID)))
+		repository.MustDeleteAny(ctx, rel.From("scores").Where(where.Eq("id", score.ID)))
+		repository.MustDeleteAny(ctx, rel.From("rewards").Where(where.Eq("id", reward.ID)))
+	})
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var redemption Redemption
+			err := service.Redeem(ctx, &redemption, reward, userID)
+			if err == nil {
+				mutex.Lock()
+				redeemed++
+				mutex.Unlock()
+				return
+			}
+
+			assert.True(t, errors.Is(err, scores.ErrPointInsufficient), err)
+		}()
+	}
+
+	wg.Wait()
+
+	repository.MustFind(ctx, &score, where.Eq("id", score.ID))
+	assert.Equal(t, 2, redeemed)
+	assert.Equal(t, 5, score.TotalPoint)
+	assert.Equal(t, 2, repository.MustCount(ctx, "redemptions", where.Eq("user_id", userID)))
+}
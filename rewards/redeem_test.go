@@ -0,0 +1,96 @@
+package rewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeem(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = New(repository, scoresSvc)
+		reward     = Reward{ID: 2, Name: "Coffee", Cost: 10}
+		redemption Redemption
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Redemption{UserID: 1, RewardID: 2, Name: "Coffee", Cost: 10})
+		scorestest.Mock(scoresSvc, scorestest.MockSpend(1, PointRedeemed, 10, scores.Source{Type: SourceRedemption, ID: 1}, nil))
+	})
+
+	assert.Nil(t, service.Redeem(ctx, &redemption, reward, 1))
+	assert.Equal(t, 1, redemption.ID)
+	assert.Equal(t, 2, redemption.RewardID)
+
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestRedeem_free(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = New(repository, scoresSvc)
+		reward     = Reward{ID: 2, Name: "Sticker", Cost: 0}
+		redemption Redemption
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Redemption{UserID: 1, RewardID: 2, Name: "Sticker", Cost: 0})
+	})
+
+	assert.Nil(t, service.Redeem(ctx, &redemption, reward, 1))
+
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestRedeem_insufficient(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = New(repository, scoresSvc)
+		reward     = Reward{ID: 2, Name: "Coffee", Cost: 10}
+		redemption Redemption
+	)
+
+	// the redemption is rolled back together with the transaction.
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Redemption{UserID: 1, RewardID: 2, Name: "Coffee", Cost: 10})
+		scorestest.Mock(scoresSvc, scorestest.MockSpend(1, PointRedeemed, 10, scores.Source{Type: SourceRedemption, ID: 1}, scores.ErrPointInsufficient))
+	})
+
+	assert.Equal(t, scores.ErrPointInsufficient, service.Redeem(ctx, &redemption, reward, 1))
+
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
+
+func TestRedeem_insertError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scoresSvc  = &scorestest.Service{}
+		service    = New(repository, scoresSvc)
+		reward     = Reward{ID: 2, Name: "Coffee", Cost: 10}
+		redemption Redemption
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Redeem(ctx, &redemption, reward, 1))
+
+	repository.AssertExpectations(t)
+	scoresSvc.AssertExpectations(t)
+}
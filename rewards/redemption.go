@@ -0,0 +1,24 @@
+package rewards
+
+import (
+	"time"
+)
+
+const (
+	// SourceRedemption is the source type of points spent on a redemption.
+	SourceRedemption = "redemption"
+	// PointRedeemed is the name of points spent on a redemption.
+	PointRedeemed = "reward redeemed"
+)
+
+// Redemption of a reward by a user.
+type Redemption struct {
+	ID       int `json:"id"`
+	UserID   int `json:"user_id"`
+	RewardID int `json:"reward_id"`
+	// Name and Cost of the reward at the time of redemption.
+	Name      string    `json:"name"`
+	Cost      int       `json:"cost"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package rewards
+
+import (
+	"time"
+)
+
+// Reward in the catalogue that can be redeemed using points.
+type Reward struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Cost        int       `json:"cost"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,53 @@
+package rewardstest
+
+import (
+	context "context"
+
+	rewards "github.com/go-rel/gin-example/rewards"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock reward functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockCatalogue util.
+func MockCatalogue(result []rewards.Reward, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Catalogue", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *[]rewards.Reward) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockRedeem util.
+func MockRedeem(result rewards.Redemption, reward rewards.Reward, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Redeem", mock.Anything, mock.Anything, reward, userID).
+			Return(func(ctx context.Context, out *rewards.Redemption, reward rewards.Reward, userID int) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockHistory util.
+func MockHistory(result []rewards.Redemption, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("History", mock.Anything, mock.Anything, userID).
+			Return(func(ctx context.Context, out *[]rewards.Redemption, userID int) error {
+				*out = result
+				return err
+			})
+	}
+}
@@ -0,0 +1,37 @@
+package rewards
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/rel"
+)
+
+//go:generate mockery --name=Service --case=underscore --output rewardstest --outpkg rewardstest
+
+// Service instance for reward's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	Catalogue(ctx context.Context, rewards *[]Reward) error
+	Redeem(ctx context.Context, redemption *Redemption, reward Reward, userID int) error
+	History(ctx context.Context, redemptions *[]Redemption, userID int) error
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	catalogue
+	redeem
+	history
+}
+
+var _ Service = (*service)(nil)
+
+// New Rewards service.
+func New(repository rel.Repository, scores scores.Service) Service {
+	return service{
+		catalogue: catalogue{repository: repository},
+		redeem:    redeem{repository: repository, scores: scores},
+		history:   history{repository: repository},
+	}
+}
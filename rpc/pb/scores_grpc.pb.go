@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: scores.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScoreService_GetScore_FullMethodName       = "/ginexample.v1.ScoreService/GetScore"
+	ScoreService_ListPoints_FullMethodName     = "/ginexample.v1.ScoreService/ListPoints"
+	ScoreService_GetLeaderboard_FullMethodName = "/ginexample.v1.ScoreService/GetLeaderboard"
+)
+
+// ScoreServiceClient is the client API for ScoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoreService queries score of the user set in x-user-id metadata.
+type ScoreServiceClient interface {
+	GetScore(ctx context.Context, in *GetScoreRequest, opts ...grpc.CallOption) (*Score, error)
+	ListPoints(ctx context.Context, in *ListPointsRequest, opts ...grpc.CallOption) (*ListPointsResponse, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+}
+
+type scoreServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreServiceClient(cc grpc.ClientConnInterface) ScoreServiceClient {
+	return &scoreServiceClient{cc}
+}
+
+func (c *scoreServiceClient) GetScore(ctx context.Context, in *GetScoreRequest, opts ...grpc.CallOption) (*Score, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Score)
+	err := c.cc.Invoke(ctx, ScoreService_GetScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreServiceClient) ListPoints(ctx context.Context, in *ListPointsRequest, opts ...grpc.CallOption) (*ListPointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPointsResponse)
+	err := c.cc.Invoke(ctx, ScoreService_ListPoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, ScoreService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoreServiceServer is the server API for ScoreService service.
+// All implementations must embed UnimplementedScoreServiceServer
+// for forward compatibility.
+//
+// ScoreService queries score of the user set in x-user-id metadata.
+type ScoreServiceServer interface {
+	GetScore(context.Context, *GetScoreRequest) (*Score, error)
+	ListPoints(context.Context, *ListPointsRequest) (*ListPointsResponse, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error)
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+// UnimplementedScoreServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoreServiceServer struct{}
+
+func (UnimplementedScoreServiceServer) GetScore(context.Context, *GetScoreRequest) (*Score, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScore not implemented")
+}
+func (UnimplementedScoreServiceServer) ListPoints(context.Context, *ListPointsRequest) (*ListPointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPoints not implemented")
+}
+func (UnimplementedScoreServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedScoreServiceServer) mustEmbedUnimplementedScoreServiceServer() {}
+func (UnimplementedScoreServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeScoreServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoreServiceServer will
+// result in compilation errors.
+type UnsafeScoreServiceServer interface {
+	mustEmbedUnimplementedScoreServiceServer()
+}
+
+func RegisterScoreServiceServer(s grpc.ServiceRegistrar, srv ScoreServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScoreServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoreService_ServiceDesc, srv)
+}
+
+func _ScoreService_GetScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).GetScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_GetScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).GetScore(ctx, req.(*GetScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreService_ListPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).ListPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_ListPoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).ListPoints(ctx, req.(*ListPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScoreService_ServiceDesc is the grpc.ServiceDesc for ScoreService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoreService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ginexample.v1.ScoreService",
+	HandlerType: (*ScoreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetScore",
+			Handler:    _ScoreService_GetScore_Handler,
+		},
+		{
+			MethodName: "ListPoints",
+			Handler:    _ScoreService_ListPoints_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _ScoreService_GetLeaderboard_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scores.proto",
+}
@@ -0,0 +1,126 @@
+// Package rpc serves todos and scores over gRPC for internal services.
+// It shares domain services with the http api, so both apis behave the same way and publish the same events.
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"strconv"
+	"strings"
+
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate protoc --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative -I pb todos.proto scores.proto
+
+const (
+	// UserIDMetadata is set by the calling service with the id of the user the call is made on behalf of.
+	UserIDMetadata = "x-user-id"
+	// AuthorizationMetadata is set by the calling service with the shared token, eg. "Bearer <token>".
+	AuthorizationMetadata = "authorization"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "rpc")))
+
+	// statusCodes of problem statuses, domain errors are mapped to problem types the same way as the http api.
+	statusCodes = map[int]codes.Code{
+		400: codes.InvalidArgument,
+		401: codes.Unauthenticated,
+		403: codes.PermissionDenied,
+		404: codes.NotFound,
+		409: codes.AlreadyExists,
+		422: codes.InvalidArgument,
+	}
+)
+
+type userIDKey struct{}
+
+// New gRPC server serving the services.
+// Calling service is authenticated by the shared token before the user it calls on behalf of is trusted,
+// every call is rejected when the token is empty.
+func New(repository rel.Repository, services services.Services, token string, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(recovery, authenticate(token), auth)}, opts...)
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterTodoServiceServer(server, NewTodos(repository, services.Todos))
+	pb.RegisterScoreServiceServer(server, NewScores(services.Scores))
+
+	return server
+}
+
+// authenticate is interceptor that authenticates the calling service by the shared token in authorization metadata.
+func authenticate(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(AuthorizationMetadata)
+		if token == "" || len(values) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "Unauthenticated")
+		}
+
+		bearer, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "Unauthenticated")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// auth is interceptor that authenticates the user in x-user-id metadata.
+func auth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(UserIDMetadata)
+	if len(values) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "Unauthenticated")
+	}
+
+	id, err := strconv.Atoi(values[0])
+	if err != nil || id <= 0 {
+		return nil, status.Error(codes.Unauthenticated, "Unauthenticated")
+	}
+
+	return handler(context.WithValue(ctx, userIDKey{}, id), req)
+}
+
+// userID of the authenticated user.
+func userID(ctx context.Context) int {
+	id, _ := ctx.Value(userIDKey{}).(int)
+	return id
+}
+
+// recovery is interceptor that logs panic and responds internal error, like recovery middleware of the http api.
+func recovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("panic", zap.Any("error", r), zap.String("method", info.FullMethod))
+			err = status.Error(codes.Internal, problem.TypeInternal.Title)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// statusError maps err to grpc status, only message of known errors is exposed.
+func statusError(err error) error {
+	typ, detail, ok := problem.Lookup(err)
+	if !ok {
+		logger.Error("internal error", zap.Error(err))
+		return status.Error(codes.Internal, problem.TypeInternal.Title)
+	}
+
+	code, ok := statusCodes[typ.Status]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	return status.Error(code, detail)
+}
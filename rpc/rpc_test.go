@@ -0,0 +1,180 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/go-rel/gin-example/rpc"
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const token = "secret"
+
+// dial in-process server serving the services.
+func dial(t *testing.T, repository rel.Repository, services services.Services) *grpc.ClientConn {
+	return dialServer(t, rpc.New(repository, services, token))
+}
+
+// dialServer serving in-process.
+func dialServer(t *testing.T, server *grpc.Server) *grpc.ClientConn {
+	var (
+		listener = bufconn.Listen(1024 * 1024)
+	)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// userContext of calls made by authenticated service on behalf of the user.
+func userContext(userID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationMetadata, "Bearer "+token, rpc.UserIDMetadata, userID)
+}
+
+// assertMessage compares message with expected json representation.
+func assertMessage(t *testing.T, expected string, message proto.Message) {
+	actual, err := protojson.Marshal(message)
+	assert.Nil(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+func TestNew_auth(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{
+			name: "missing token",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), rpc.UserIDMetadata, "1"),
+		},
+		{
+			name: "invalid token",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationMetadata, "Bearer guess", rpc.UserIDMetadata, "1"),
+		},
+		{
+			name: "token without bearer",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationMetadata, token, rpc.UserIDMetadata, "1"),
+		},
+		{
+			name: "missing user id",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationMetadata, "Bearer "+token),
+		},
+		{
+			name: "invalid user id",
+			ctx:  userContext("abc"),
+		},
+		{
+			name: "non positive user id",
+			ctx:  userContext("0"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				todos  = &todostest.Service{}
+				client = pb.NewTodoServiceClient(dial(t, reltest.New(), services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+			)
+
+			_, err := client.SearchTodos(test.ctx, &pb.SearchTodosRequest{})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNew_emptyToken(t *testing.T) {
+	var (
+		todos  = &todostest.Service{}
+		server = rpc.New(reltest.New(), services.Services{Todos: todos, Scores: &scorestest.Service{}}, "")
+		client = pb.NewTodoServiceClient(dialServer(t, server))
+		ctx    = metadata.AppendToOutgoingContext(context.Background(), rpc.AuthorizationMetadata, "Bearer ", rpc.UserIDMetadata, "1")
+	)
+
+	_, err := client.SearchTodos(ctx, &pb.SearchTodosRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	todos.AssertExpectations(t)
+}
+
+func TestNew_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      codes.Code
+		message   string
+		mockTodos func(todos *todostest.Service)
+	}{
+		{
+			name:      "known error",
+			code:      codes.InvalidArgument,
+			message:   "Title can't be blank",
+			mockTodos: todostest.MockCreate(todos.Todo{}, todos.ErrTodoTitleBlank),
+		},
+		{
+			name:      "conflict",
+			code:      codes.AlreadyExists,
+			message:   "Resource already exists",
+			mockTodos: todostest.MockCreate(todos.Todo{}, rel.ConstraintError{Type: rel.UniqueConstraint}),
+		},
+		{
+			name:      "unknown error",
+			code:      codes.Internal,
+			message:   "Internal Server Error",
+			mockTodos: todostest.MockCreate(todos.Todo{}, errors.New("connection lost")),
+		},
+		{
+			name:    "panic",
+			code:    codes.Internal,
+			message: "Internal Server Error",
+			mockTodos: func(todos *todostest.Service) {
+				todos.On("Create", mock.Anything, mock.Anything).Panic("connection lost")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				todos  = &todostest.Service{}
+				client = pb.NewTodoServiceClient(dial(t, reltest.New(), services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+			)
+
+			todostest.Mock(todos, test.mockTodos)
+
+			_, err := client.CreateTodo(userContext("1"), &pb.CreateTodoRequest{Todo: &pb.Todo{Title: "Sleep"}})
+			assert.Equal(t, test.code, status.Code(err))
+			assert.Equal(t, test.message, status.Convert(err).Message())
+
+			todos.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/scores"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Scores implements ScoreService.
+type Scores struct {
+	pb.UnimplementedScoreServiceServer
+	scores scores.Service
+}
+
+// GetScore of the user.
+func (s Scores) GetScore(ctx context.Context, req *pb.GetScoreRequest) (*pb.Score, error) {
+	var score scores.Score
+	if err := s.scores.Find(ctx, &score, userID(ctx)); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.Score{
+		Id:            int64(score.ID),
+		UserId:        int64(score.UserID),
+		TotalPoint:    int64(score.TotalPoint),
+		CurrentStreak: int64(score.CurrentStreak),
+		LongestStreak: int64(score.LongestStreak),
+		StreakOn:      score.StreakOn,
+		Level:         int64(score.Level),
+		LevelProgress: int64(score.LevelProgress),
+		NextLevelAt:   int64(score.NextLevelAt),
+		CreatedAt:     timestamppb.New(score.CreatedAt),
+		UpdatedAt:     timestamppb.New(score.UpdatedAt),
+	}, nil
+}
+
+// ListPoints of the user, newest first.
+func (s Scores) ListPoints(ctx context.Context, req *pb.ListPointsRequest) (*pb.ListPointsResponse, error) {
+	filter := scores.PointFilter{
+		UserID:     userID(ctx),
+		Name:       req.Name,
+		SourceType: req.SourceType,
+		SourceID:   int(req.SourceId),
+		Cursor:     int(req.Cursor),
+		Limit:      int(req.Limit),
+	}
+
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	page, err := s.scores.Points(ctx, filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &pb.ListPointsResponse{
+		Points:     make([]*pb.Point, len(page.Points)),
+		NextCursor: int64(page.NextCursor),
+	}
+
+	for i, point := range page.Points {
+		response.Points[i] = &pb.Point{
+			Id:         int64(point.ID),
+			Name:       point.Name,
+			Count:      int64(point.Count),
+			SourceType: point.SourceType,
+			SourceId:   int64(point.SourceID),
+			CreatedAt:  timestamppb.New(point.CreatedAt),
+		}
+	}
+
+	return response, nil
+}
+
+// GetLeaderboard of users ranked by points earned within the window.
+func (s Scores) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.Leaderboard, error) {
+	leaderboard, err := s.scores.Leaderboard(ctx, userID(ctx), req.Window, int(req.Limit))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &pb.Leaderboard{
+		Window:  leaderboard.Window,
+		Entries: make([]*pb.LeaderboardEntry, len(leaderboard.Entries)),
+		Me:      leaderboardEntryMessage(leaderboard.Me),
+	}
+
+	if leaderboard.Since != nil {
+		response.Since = timestamppb.New(*leaderboard.Since)
+	}
+
+	for i := range leaderboard.Entries {
+		response.Entries[i] = leaderboardEntryMessage(leaderboard.Entries[i])
+	}
+
+	return response, nil
+}
+
+// NewScores server.
+func NewScores(scores scores.Service) Scores {
+	return Scores{
+		scores: scores,
+	}
+}
+
+func leaderboardEntryMessage(entry scores.LeaderboardEntry) *pb.LeaderboardEntry {
+	return &pb.LeaderboardEntry{
+		Rank:   int64(entry.Rank),
+		UserId: int64(entry.UserID),
+		Points: int64(entry.Points),
+	}
+}
@@ -0,0 +1,145 @@
+package rpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestScores_GetScore(t *testing.T) {
+	var (
+		score  = scores.Score{ID: 1, UserID: 1, TotalPoint: 120, CurrentStreak: 2, LongestStreak: 5, StreakOn: "2026-10-19", Level: 2, LevelProgress: 20, NextLevelAt: 250}
+		scores = &scorestest.Service{}
+		client = pb.NewScoreServiceClient(dial(t, reltest.New(), services.Services{Todos: &todostest.Service{}, Scores: scores}))
+	)
+
+	scorestest.Mock(scores, scorestest.MockFind(score, 1, nil))
+
+	response, err := client.GetScore(userContext("1"), &pb.GetScoreRequest{})
+	assert.Nil(t, err)
+	assertMessage(t, `{"id":"1", "userId":"1", "totalPoint":"120", "currentStreak":"2", "longestStreak":"5", "streakOn":"2026-10-19", "level":"2", "levelProgress":"20", "nextLevelAt":"250", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}`, response)
+
+	scores.AssertExpectations(t)
+}
+
+func TestScores_ListPoints(t *testing.T) {
+	var (
+		from = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		to   = time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+		page = scores.PointPage{
+			Points:     []scores.Point{{ID: 3, Name: "todo_completed", Count: 10, SourceType: "todo", SourceID: 1}},
+			NextCursor: 3,
+		}
+	)
+
+	tests := []struct {
+		name             string
+		request          *pb.ListPointsRequest
+		code             codes.Code
+		response         string
+		mockScoresPoints func(scores *scorestest.Service)
+	}{
+		{
+			name:             "ok",
+			request:          &pb.ListPointsRequest{Name: "todo_completed", Limit: 1},
+			code:             codes.OK,
+			response:         `{"points":[{"id":"3", "name":"todo_completed", "count":"10", "sourceType":"todo", "sourceId":"1", "createdAt":"0001-01-01T00:00:00Z"}], "nextCursor":"3"}`,
+			mockScoresPoints: scorestest.MockPoints(page, scores.PointFilter{UserID: 1, Name: "todo_completed", Limit: 1}, nil),
+		},
+		{
+			name:             "with range and cursor",
+			request:          &pb.ListPointsRequest{From: timestamppb.New(from), To: timestamppb.New(to), Cursor: 10},
+			code:             codes.OK,
+			response:         `{"points":[{"id":"3", "name":"todo_completed", "count":"10", "sourceType":"todo", "sourceId":"1", "createdAt":"0001-01-01T00:00:00Z"}], "nextCursor":"3"}`,
+			mockScoresPoints: scorestest.MockPoints(page, scores.PointFilter{UserID: 1, From: from, To: to, Cursor: 10}, nil),
+		},
+		{
+			name:             "invalid range",
+			request:          &pb.ListPointsRequest{From: timestamppb.New(to), To: timestamppb.New(from)},
+			code:             codes.InvalidArgument,
+			mockScoresPoints: scorestest.MockPoints(scores.PointPage{}, scores.PointFilter{UserID: 1, From: to, To: from}, scores.ErrPointRangeInvalid),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				scores = &scorestest.Service{}
+				client = pb.NewScoreServiceClient(dial(t, reltest.New(), services.Services{Todos: &todostest.Service{}, Scores: scores}))
+			)
+
+			scorestest.Mock(scores, test.mockScoresPoints)
+
+			response, err := client.ListPoints(userContext("1"), test.request)
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assertMessage(t, test.response, response)
+			}
+
+			scores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScores_GetLeaderboard(t *testing.T) {
+	var (
+		since       = time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+		leaderboard = scores.Leaderboard{
+			Window:  "week",
+			Since:   &since,
+			Entries: []scores.LeaderboardEntry{{Rank: 1, UserID: 2, Points: 50}},
+			Me:      scores.LeaderboardEntry{Rank: 4, UserID: 1, Points: 10},
+		}
+	)
+
+	tests := []struct {
+		name                  string
+		request               *pb.GetLeaderboardRequest
+		code                  codes.Code
+		response              string
+		mockScoresLeaderboard func(scores *scorestest.Service)
+	}{
+		{
+			name:                  "ok",
+			request:               &pb.GetLeaderboardRequest{Window: "week", Limit: 1},
+			code:                  codes.OK,
+			response:              `{"window":"week", "since":"2026-10-13T00:00:00Z", "entries":[{"rank":"1", "userId":"2", "points":"50"}], "me":{"rank":"4", "userId":"1", "points":"10"}}`,
+			mockScoresLeaderboard: scorestest.MockLeaderboard(leaderboard, 1, "week", 1, nil),
+		},
+		{
+			name:                  "invalid window",
+			request:               &pb.GetLeaderboardRequest{Window: "year"},
+			code:                  codes.InvalidArgument,
+			mockScoresLeaderboard: scorestest.MockLeaderboard(scores.Leaderboard{}, 1, "year", 0, scores.ErrLeaderboardWindowInvalid),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				scores = &scorestest.Service{}
+				client = pb.NewScoreServiceClient(dial(t, reltest.New(), services.Services{Todos: &todostest.Service{}, Scores: scores}))
+			)
+
+			scorestest.Mock(scores, test.mockScoresLeaderboard)
+
+			response, err := client.GetLeaderboard(userContext("1"), test.request)
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assertMessage(t, test.response, response)
+			}
+
+			scores.AssertExpectations(t)
+		})
+	}
+}
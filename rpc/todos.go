@@ -0,0 +1,194 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-rel/gin-example/api/middleware"
+	"github.com/go-rel/gin-example/api/problem"
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrTodoRequired error.
+	ErrTodoRequired = problem.BadRequest(errors.New("Todo is required"))
+)
+
+// todoPaths are the writable fields of a todo, in update mask format.
+var todoPaths = []string{"title", "notes", "completed", "order", "due_at", "priority", "list", "tags"}
+
+// Todos implements TodoService.
+type Todos struct {
+	pb.UnimplementedTodoServiceServer
+	repository rel.Repository
+	todos      todos.Service
+}
+
+// SearchTodos of the user.
+func (t Todos) SearchTodos(ctx context.Context, req *pb.SearchTodosRequest) (*pb.SearchTodosResponse, error) {
+	var (
+		result []todos.Todo
+		filter = todos.Filter{
+			UserID:    userID(ctx),
+			Keyword:   req.Keyword,
+			Completed: req.Completed,
+		}
+	)
+
+	if err := t.todos.Search(ctx, &result, filter); err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &pb.SearchTodosResponse{Todos: make([]*pb.Todo, len(result))}
+	for i := range result {
+		response.Todos[i] = todoMessage(result[i])
+	}
+
+	return response, nil
+}
+
+// GetTodo by id.
+func (t Todos) GetTodo(ctx context.Context, req *pb.GetTodoRequest) (*pb.Todo, error) {
+	todo, err := t.load(ctx, req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return todoMessage(todo), nil
+}
+
+// CreateTodo for the user.
+func (t Todos) CreateTodo(ctx context.Context, req *pb.CreateTodoRequest) (*pb.Todo, error) {
+	if req.Todo == nil {
+		return nil, statusError(ErrTodoRequired)
+	}
+
+	var todo todos.Todo
+	if err := applyTodo(&todo, req.Todo, todoPaths); err != nil {
+		return nil, statusError(err)
+	}
+
+	todo.UserID = userID(ctx)
+	if err := t.todos.Create(ctx, &todo); err != nil {
+		return nil, statusError(err)
+	}
+
+	return todoMessage(todo), nil
+}
+
+// UpdateTodo fields listed in update mask.
+func (t Todos) UpdateTodo(ctx context.Context, req *pb.UpdateTodoRequest) (*pb.Todo, error) {
+	if req.Todo == nil {
+		return nil, statusError(ErrTodoRequired)
+	}
+
+	todo, err := t.load(ctx, req.Todo.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	var (
+		changes = rel.NewChangeset(&todo)
+		paths   = req.UpdateMask.GetPaths()
+	)
+
+	if len(paths) == 0 {
+		paths = todoPaths
+	}
+
+	if err := applyTodo(&todo, req.Todo, paths); err != nil {
+		return nil, statusError(err)
+	}
+
+	if err := t.todos.Update(ctx, &todo, changes); err != nil {
+		return nil, statusError(err)
+	}
+
+	return todoMessage(todo), nil
+}
+
+// DeleteTodo by id.
+func (t Todos) DeleteTodo(ctx context.Context, req *pb.DeleteTodoRequest) (*emptypb.Empty, error) {
+	todo, err := t.load(ctx, req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	t.todos.Delete(ctx, &todo)
+	return &emptypb.Empty{}, nil
+}
+
+// load todo owned by the user.
+func (t Todos) load(ctx context.Context, id int64) (todos.Todo, error) {
+	var todo todos.Todo
+	err := t.repository.Find(ctx, &todo, where.Eq("id", id).AndEq("user_id", userID(ctx)))
+	return todo, err
+}
+
+// NewTodos server.
+func NewTodos(repository rel.Repository, todos todos.Service) Todos {
+	return Todos{
+		repository: repository,
+		todos:      todos,
+	}
+}
+
+func todoMessage(todo todos.Todo) *pb.Todo {
+	message := &pb.Todo{
+		Id:        int64(todo.ID),
+		Title:     todo.Title,
+		Notes:     todo.Notes,
+		Completed: todo.Completed,
+		Order:     int64(todo.Order),
+		Priority:  pb.Priority(todo.Priority),
+		List:      todo.List,
+		Tags:      todo.Tags,
+		Url:       fmt.Sprint(todos.TodoURLPrefix(middleware.VersionV2), todo.ID),
+		CreatedAt: timestamppb.New(todo.CreatedAt),
+		UpdatedAt: timestamppb.New(todo.UpdatedAt),
+	}
+
+	if todo.DueAt != nil {
+		message.DueAt = timestamppb.New(*todo.DueAt)
+	}
+
+	return message
+}
+
+// applyTodo sets fields of the todo listed in paths from the message.
+func applyTodo(todo *todos.Todo, message *pb.Todo, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "title":
+			todo.Title = message.Title
+		case "notes":
+			todo.Notes = message.Notes
+		case "completed":
+			todo.Completed = message.Completed
+		case "order":
+			todo.Order = int(message.Order)
+		case "due_at":
+			todo.DueAt = nil
+			if message.DueAt != nil {
+				dueAt := message.DueAt.AsTime()
+				todo.DueAt = &dueAt
+			}
+		case "priority":
+			todo.Priority = todos.Priority(message.Priority)
+		case "list":
+			todo.List = message.List
+		case "tags":
+			todo.Tags = message.Tags
+		default:
+			return problem.BadRequest(fmt.Errorf("Update mask path %s is not writable", path))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package rpc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/rpc/pb"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/gin-example/services"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/todos/todostest"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTodos_SearchTodos(t *testing.T) {
+	var (
+		trueb  = true
+		dueAt  = time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+		result = []todos.Todo{{ID: 1, UserID: 1, Title: "Wake", Completed: true, DueAt: &dueAt, Priority: todos.PriorityHigh, Tags: todos.Tags{"home"}}}
+	)
+
+	tests := []struct {
+		name            string
+		request         *pb.SearchTodosRequest
+		response        string
+		mockTodosSearch func(todos *todostest.Service)
+	}{
+		{
+			name:            "ok",
+			request:         &pb.SearchTodosRequest{},
+			response:        `{"todos":[{"id":"2", "title":"Sleep", "url":"v2/todos/2", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}]}`,
+			mockTodosSearch: todostest.MockSearch([]todos.Todo{{ID: 2, UserID: 1, Title: "Sleep"}}, todos.Filter{UserID: 1}, nil),
+		},
+		{
+			name:            "with keyword and filter completed",
+			request:         &pb.SearchTodosRequest{Keyword: "Wake", Completed: &trueb},
+			response:        `{"todos":[{"id":"1", "title":"Wake", "completed":true, "dueAt":"2026-10-20T00:00:00Z", "priority":"PRIORITY_HIGH", "tags":["home"], "url":"v2/todos/1", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}]}`,
+			mockTodosSearch: todostest.MockSearch(result, todos.Filter{UserID: 1, Keyword: "Wake", Completed: &trueb}, nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				todos  = &todostest.Service{}
+				client = pb.NewTodoServiceClient(dial(t, reltest.New(), services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+			)
+
+			todostest.Mock(todos, test.mockTodosSearch)
+
+			response, err := client.SearchTodos(userContext("1"), test.request)
+			assert.Nil(t, err)
+			assertMessage(t, test.response, response)
+
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTodos_GetTodo(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *pb.GetTodoRequest
+		code     codes.Code
+		response string
+		mockRepo func(repo *reltest.Repository)
+	}{
+		{
+			name:     "ok",
+			request:  &pb.GetTodoRequest{Id: 1},
+			code:     codes.OK,
+			response: `{"id":"1", "title":"Sleep", "url":"v2/todos/1", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(1)).AndEq("user_id", 1)).Result(todos.Todo{ID: 1, UserID: 1, Title: "Sleep"})
+			},
+		},
+		{
+			name:    "not found",
+			request: &pb.GetTodoRequest{Id: 2},
+			code:    codes.NotFound,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(2)).AndEq("user_id", 1)).NotFound()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				repository = reltest.New()
+				client     = pb.NewTodoServiceClient(dial(t, repository, services.Services{Todos: &todostest.Service{}, Scores: &scorestest.Service{}}))
+			)
+
+			test.mockRepo(repository)
+
+			response, err := client.GetTodo(userContext("1"), test.request)
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assertMessage(t, test.response, response)
+			}
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTodos_CreateTodo(t *testing.T) {
+	var (
+		dueAt = time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name            string
+		request         *pb.CreateTodoRequest
+		code            codes.Code
+		response        string
+		mockTodosCreate func(todos *todostest.Service)
+	}{
+		{
+			name:            "ok",
+			request:         &pb.CreateTodoRequest{Todo: &pb.Todo{Title: "Sleep", DueAt: timestamppb.New(dueAt), Priority: pb.Priority_PRIORITY_LOW}},
+			code:            codes.OK,
+			response:        `{"id":"1", "title":"Sleep", "dueAt":"2026-10-20T00:00:00Z", "priority":"PRIORITY_LOW", "url":"v2/todos/1", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}`,
+			mockTodosCreate: todostest.MockCreate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", DueAt: &dueAt, Priority: todos.PriorityLow}, nil),
+		},
+		{
+			name:    "todo is required",
+			request: &pb.CreateTodoRequest{},
+			code:    codes.InvalidArgument,
+		},
+		{
+			name:            "validation error",
+			request:         &pb.CreateTodoRequest{Todo: &pb.Todo{Priority: pb.Priority(5)}},
+			code:            codes.InvalidArgument,
+			mockTodosCreate: todostest.MockCreate(todos.Todo{}, todos.ErrTodoPriorityInvalid),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				todos  = &todostest.Service{}
+				client = pb.NewTodoServiceClient(dial(t, reltest.New(), services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+			)
+
+			todostest.Mock(todos, test.mockTodosCreate)
+
+			response, err := client.CreateTodo(userContext("1"), test.request)
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assertMessage(t, test.response, response)
+			}
+
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTodos_UpdateTodo(t *testing.T) {
+	var (
+		todo = todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Notes: "zzz"}
+	)
+
+	tests := []struct {
+		name            string
+		request         *pb.UpdateTodoRequest
+		code            codes.Code
+		response        string
+		mockRepo        func(repo *reltest.Repository)
+		mockTodosUpdate func(todos *todostest.Service)
+	}{
+		{
+			name:     "update masked fields",
+			request:  &pb.UpdateTodoRequest{Todo: &pb.Todo{Id: 1, Completed: true}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"completed"}}},
+			code:     codes.OK,
+			response: `{"id":"1", "title":"Sleep", "notes":"zzz", "completed":true, "url":"v2/todos/1", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(1)).AndEq("user_id", 1)).Result(todo)
+			},
+			mockTodosUpdate: todostest.MockUpdate(todos.Todo{ID: 1, UserID: 1, Title: "Sleep", Notes: "zzz", Completed: true}, nil),
+		},
+		{
+			name:     "update every field without mask",
+			request:  &pb.UpdateTodoRequest{Todo: &pb.Todo{Id: 1, Title: "Wake"}},
+			code:     codes.OK,
+			response: `{"id":"1", "title":"Wake", "url":"v2/todos/1", "createdAt":"0001-01-01T00:00:00Z", "updatedAt":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(1)).AndEq("user_id", 1)).Result(todo)
+			},
+			mockTodosUpdate: todostest.MockUpdate(todos.Todo{ID: 1, UserID: 1, Title: "Wake"}, nil),
+		},
+		{
+			name:    "read only field in mask",
+			request: &pb.UpdateTodoRequest{Todo: &pb.Todo{Id: 1}, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"url"}}},
+			code:    codes.InvalidArgument,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(1)).AndEq("user_id", 1)).Result(todo)
+			},
+		},
+		{
+			name:    "not found",
+			request: &pb.UpdateTodoRequest{Todo: &pb.Todo{Id: 2}},
+			code:    codes.NotFound,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", int64(2)).AndEq("user_id", 1)).NotFound()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				repository = reltest.New()
+				todos      = &todostest.Service{}
+				client     = pb.NewTodoServiceClient(dial(t, repository, services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+			)
+
+			test.mockRepo(repository)
+			todostest.Mock(todos, test.mockTodosUpdate)
+
+			response, err := client.UpdateTodo(userContext("1"), test.request)
+			assert.Equal(t, test.code, status.Code(err))
+			if test.code == codes.OK {
+				assertMessage(t, test.response, response)
+			}
+
+			repository.AssertExpectations(t)
+			todos.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTodos_DeleteTodo(t *testing.T) {
+	var (
+		todo       = todos.Todo{ID: 1, UserID: 1, Title: "Sleep"}
+		repository = reltest.New()
+		todos      = &todostest.Service{}
+		client     = pb.NewTodoServiceClient(dial(t, repository, services.Services{Todos: todos, Scores: &scorestest.Service{}}))
+	)
+
+	repository.ExpectFind(where.Eq("id", int64(1)).AndEq("user_id", 1)).Result(todo)
+	todostest.Mock(todos, todostest.MockDelete())
+
+	_, err := client.DeleteTodo(userContext("1"), &pb.DeleteTodoRequest{Id: 1})
+	assert.Nil(t, err)
+
+	repository.AssertExpectations(t)
+	todos.AssertExpectations(t)
+}
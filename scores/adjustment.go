@@ -0,0 +1,95 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+)
+
+const (
+	// SourceAdjustment is the source type of points adjusted manually by an admin.
+	SourceAdjustment = "adjustment"
+	// PointAdjusted is the name of points adjusted manually by an admin.
+	PointAdjusted = "score adjusted"
+)
+
+var (
+	// ErrAdjustmentUserInvalid validation error.
+	ErrAdjustmentUserInvalid = errors.New("User is invalid")
+	// ErrAdjustmentCountInvalid validation error.
+	ErrAdjustmentCountInvalid = errors.New("Count can't be zero")
+	// ErrAdjustmentReasonBlank validation error.
+	ErrAdjustmentReasonBlank = errors.New("Reason can't be blank")
+	// ErrAdjustmentNegative error.
+	ErrAdjustmentNegative = errors.New("Adjustment would make total point negative, force is required")
+)
+
+// Adjustment of user's points done manually by an admin, kept for audit.
+type Adjustment struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Count  int    `json:"count"`
+	Reason string `json:"reason"`
+	// AdjustedBy is the user id of the admin.
+	AdjustedBy int `json:"adjusted_by"`
+	// Forced adjustment is applied even when it makes total point negative.
+	Forced    bool      `json:"forced"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate adjustment.
+func (a Adjustment) Validate() error {
+	var err error
+	switch {
+	case a.UserID <= 0:
+		err = ErrAdjustmentUserInvalid
+	case a.Count == 0:
+		err = ErrAdjustmentCountInvalid
+	case strings.TrimSpace(a.Reason) == "":
+		err = ErrAdjustmentReasonBlank
+	}
+
+	return err
+}
+
+type adjust struct {
+	repository rel.Repository
+	earn       earn
+	spend      spend
+}
+
+// Adjust user's points and record the adjustment, deduction that makes total point negative is refused unless forced.
+func (a adjust) Adjust(ctx context.Context, adjustment *Adjustment) error {
+	if err := adjustment.Validate(); err != nil {
+		return err
+	}
+
+	err := Retry(ctx, func(ctx context.Context) error {
+		return events.Commit(ctx, func(ctx context.Context) error {
+			return a.repository.Transaction(ctx, func(ctx context.Context) error {
+				adjustment.ID = 0
+				if err := a.repository.Insert(ctx, adjustment); err != nil {
+					return err
+				}
+
+				source := Source{Type: SourceAdjustment, ID: adjustment.ID}
+				if adjustment.Count > 0 || adjustment.Forced {
+					return a.earn.earn(ctx, adjustment.UserID, PointAdjusted, adjustment.Count, source)
+				}
+
+				return a.spend.Spend(ctx, adjustment.UserID, PointAdjusted, -adjustment.Count, source)
+			})
+		})
+	})
+
+	if errors.Is(err, ErrPointInsufficient) {
+		return ErrAdjustmentNegative
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAdjustService(repository *reltest.Repository) service {
+	service := New(repository, DefaultConfig, events.Nop{}).(service)
+	service.adjust.earn.now = func() time.Time { return earnNow }
+	service.adjust.spend.now = func() time.Time { return earnNow }
+	return service
+}
+
+func TestAdjustment_Validate(t *testing.T) {
+	assert.Nil(t, Adjustment{UserID: 1, Count: -5, Reason: "duplicate todos"}.Validate())
+	assert.Equal(t, ErrAdjustmentUserInvalid, Adjustment{Count: 5, Reason: "missing points"}.Validate())
+	assert.Equal(t, ErrAdjustmentCountInvalid, Adjustment{UserID: 1, Reason: "missing points"}.Validate())
+	assert.Equal(t, ErrAdjustmentReasonBlank, Adjustment{UserID: 1, Count: 5, Reason: " "}.Validate())
+}
+
+func TestAdjust(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: 5, Reason: "missing points", AdjustedBy: 9}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Adjustment{UserID: 1, Count: 5, Reason: "missing points", AdjustedBy: 9})
+		expectIncrement(repository, 1, 5).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 2, UserID: 1, TotalPoint: 15})
+		repository.ExpectInsert().For(&Point{Name: PointAdjusted, Count: 5, ScoreID: 2, SourceType: SourceAdjustment, SourceID: 1})
+	})
+
+	assert.Nil(t, service.Adjust(ctx, &adjustment))
+	assert.Equal(t, 1, adjustment.ID)
+	repository.AssertExpectations(t)
+}
+
+func TestAdjust_deduct(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: -5, Reason: "duplicate todos", AdjustedBy: 9}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Adjustment{UserID: 1, Count: -5, Reason: "duplicate todos", AdjustedBy: 9})
+		repository.ExpectTransaction(func(repository *reltest.Repository) {
+			expectDeduct(repository, 1, 5).UpdatedCount(1)
+			repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 2, UserID: 1, TotalPoint: 5})
+			repository.ExpectInsert().For(&Point{Name: PointAdjusted, Count: -5, ScoreID: 2, SourceType: SourceAdjustment, SourceID: 1})
+		})
+	})
+
+	assert.Nil(t, service.Adjust(ctx, &adjustment))
+	repository.AssertExpectations(t)
+}
+
+func TestAdjust_negative(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: -20, Reason: "duplicate todos", AdjustedBy: 9}
+	)
+
+	// the adjustment is rolled back together with the transaction.
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Adjustment{UserID: 1, Count: -20, Reason: "duplicate todos", AdjustedBy: 9})
+		repository.ExpectTransaction(func(repository *reltest.Repository) {
+			expectDeduct(repository, 1, 20).UpdatedCount(0)
+		})
+	})
+
+	assert.Equal(t, ErrAdjustmentNegative, service.Adjust(ctx, &adjustment))
+	repository.AssertExpectations(t)
+}
+
+func TestAdjust_forced(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: -20, Reason: "abuse", AdjustedBy: 9, Forced: true}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&Adjustment{UserID: 1, Count: -20, Reason: "abuse", AdjustedBy: 9, Forced: true})
+		expectIncrement(repository, 1, -20).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 2, UserID: 1, TotalPoint: -10})
+		repository.ExpectInsert().For(&Point{Name: PointAdjusted, Count: -20, ScoreID: 2, SourceType: SourceAdjustment, SourceID: 1})
+	})
+
+	assert.Nil(t, service.Adjust(ctx, &adjustment))
+	repository.AssertExpectations(t)
+}
+
+func TestAdjust_invalid(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: 5, AdjustedBy: 9}
+	)
+
+	assert.Equal(t, ErrAdjustmentReasonBlank, service.Adjust(ctx, &adjustment))
+	repository.AssertExpectations(t)
+}
+
+func TestAdjust_insertError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newAdjustService(repository)
+		adjustment = Adjustment{UserID: 1, Count: 5, Reason: "missing points", AdjustedBy: 9}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Adjust(ctx, &adjustment))
+	repository.AssertExpectations(t)
+}
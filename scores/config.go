@@ -0,0 +1,75 @@
+package scores
+
+import (
+	"encoding/json"
+	"os"
+)
+
+var (
+	// DefaultConfig used when no config file is configured.
+	DefaultConfig = Config{
+		Rules:  DefaultRules,
+		Streak: DefaultStreak,
+		Levels: DefaultLevels,
+	}
+)
+
+// Config of score's domain.
+type Config struct {
+	Rules  Rules  `json:"rules"`
+	Streak Streak `json:"streak"`
+	Levels Levels `json:"levels"`
+}
+
+// Validate config.
+func (c Config) Validate() error {
+	if err := c.Rules.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Streak.Validate(); err != nil {
+		return err
+	}
+
+	return c.Levels.Validate()
+}
+
+// LoadConfig from json file, returns DefaultConfig if path is empty.
+// Missing section of the file is filled using the default.
+func LoadConfig(path string) (Config, error) {
+	if path == "" {
+		return DefaultConfig, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var (
+		config = DefaultConfig
+		file   struct {
+			Rules  *Rules  `json:"rules"`
+			Streak *Streak `json:"streak"`
+			Levels *Levels `json:"levels"`
+		}
+	)
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Config{}, err
+	}
+
+	if file.Rules != nil {
+		config.Rules = *file.Rules
+	}
+
+	if file.Streak != nil {
+		config.Streak = *file.Streak
+	}
+
+	if file.Levels != nil {
+		config.Levels = *file.Levels
+	}
+
+	return config, config.Validate()
+}
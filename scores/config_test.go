@@ -0,0 +1,100 @@
+package scores
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	var (
+		dir = t.TempDir()
+	)
+
+	t.Run("default", func(t *testing.T) {
+		config, err := LoadConfig("")
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultConfig, config)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		var (
+			path = filepath.Join(dir, "config.json")
+			high = 3
+		)
+
+		os.WriteFile(path, []byte(`{
+			"rules": [{"name": "todo completed", "event": "todo.completed", "points": 1, "daily_cap": 10, "multipliers": [{"priority": 3, "factor": 2}]}],
+			"streak": {"time_zone": "Asia/Jakarta", "names": ["todo completed"], "milestones": [{"days": 3, "points": 2}]},
+			"levels": {"curve": "table", "thresholds": [10, 30, 60]}
+		}`), 0o600)
+
+		config, err := LoadConfig(path)
+		assert.Nil(t, err)
+		assert.Equal(t, Config{
+			Rules: Rules{{
+				Name:        "todo completed",
+				Event:       "todo.completed",
+				Points:      1,
+				DailyCap:    10,
+				Multipliers: []Multiplier{{Conditions: Conditions{Priority: &high}, Factor: 2}},
+			}},
+			Streak: Streak{
+				TimeZone:   "Asia/Jakarta",
+				Names:      []string{"todo completed"},
+				Milestones: []Milestone{{Days: 3, Points: 2}},
+			},
+			Levels: Levels{Curve: CurveTable, Thresholds: []int{10, 30, 60}},
+		}, config)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		path := filepath.Join(dir, "partial.json")
+		os.WriteFile(path, []byte(`{"streak": {"time_zone": "UTC"}}`), 0o600)
+
+		config, err := LoadConfig(path)
+		assert.Nil(t, err)
+		assert.Equal(t, DefaultRules, config.Rules)
+		assert.Equal(t, Streak{TimeZone: "UTC"}, config.Streak)
+		assert.Equal(t, DefaultLevels, config.Levels)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		os.WriteFile(path, []byte(`{"rules": [{"event": "todo.completed"}]}`), 0o600)
+
+		_, err := LoadConfig(path)
+		assert.Equal(t, ErrRuleNameBlank, err)
+	})
+
+	t.Run("invalid time zone", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid_tz.json")
+		os.WriteFile(path, []byte(`{"streak": {"time_zone": "Mars/Olympus"}}`), 0o600)
+
+		_, err := LoadConfig(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid levels", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid_levels.json")
+		os.WriteFile(path, []byte(`{"levels": {"curve": "exponential", "base": 10, "factor": 1}}`), 0o600)
+
+		_, err := LoadConfig(path)
+		assert.Equal(t, ErrLevelFactorInvalid, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		path := filepath.Join(dir, "malformed.json")
+		os.WriteFile(path, []byte(`{`), 0o600)
+
+		_, err := LoadConfig(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("not exists", func(t *testing.T) {
+		_, err := LoadConfig(filepath.Join(dir, "missing.json"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
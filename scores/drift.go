@@ -0,0 +1,45 @@
+package scores
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "scores")))
+)
+
+// MonitorDrift periodically reconciles scores without fixing them and logs every drift found.
+// It blocks until the context is canceled.
+func MonitorDrift(ctx context.Context, service Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkDrift(ctx, service)
+		}
+	}
+}
+
+func checkDrift(ctx context.Context, service Service) {
+	discrepancies, err := service.Reconcile(ctx, false)
+	if err != nil {
+		logger.Error("drift check error", zap.Error(err))
+		return
+	}
+
+	for _, discrepancy := range discrepancies {
+		logger.Warn("score drift detected",
+			zap.Int("score_id", discrepancy.ScoreID),
+			zap.Int("user_id", discrepancy.UserID),
+			zap.Int("total_point", discrepancy.TotalPoint),
+			zap.Int("expected", discrepancy.Expected),
+			zap.Int("drift", discrepancy.Drift()))
+	}
+}
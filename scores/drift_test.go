@@ -0,0 +1,42 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitorDrift(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.TODO())
+		repository  = reltest.New()
+		service     = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	cancel()
+	assert.NotPanics(t, func() {
+		MonitorDrift(ctx, service, time.Hour)
+	})
+	repository.AssertExpectations(t)
+}
+
+func TestCheckDrift(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	repository.ExpectFindAll(reconcileQuery).Result([]Discrepancy{{ScoreID: 1, UserID: 2, TotalPoint: 10, Expected: 12}})
+	repository.ExpectFindAll(reconcileQuery).ConnectionClosed()
+
+	assert.NotPanics(t, func() {
+		checkDrift(ctx, service)
+		checkDrift(ctx, service)
+	})
+	repository.AssertExpectations(t)
+}
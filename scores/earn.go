@@ -3,37 +3,199 @@ package scores
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
 )
 
+// streakQuery continues or restarts the streak unless it's already counted today.
+// mysql evaluates assignments from left to right, so longest_streak is compared to the updated current_streak.
+const streakQuery = "UPDATE scores SET " +
+	"current_streak = IF(streak_on = ?, current_streak + 1, 1), " +
+	"longest_streak = GREATEST(longest_streak, current_streak), " +
+	"streak_on = ? " +
+	"WHERE user_id = ? AND (streak_on IS NULL OR streak_on <> ?)"
+
 type earn struct {
 	repository rel.Repository
+	streak     Streak
+	levels     Levels
+	publisher  events.Publisher
+	listeners  []Listener
+	now        func() time.Time
+}
+
+// Earn points using atomic increments instead of locking the score before updating it,
+// the transaction is retried when it's deadlocked by concurrent earn.
+// Points with a dedupe key that's already earned are ignored.
+func (e earn) Earn(ctx context.Context, userID int, name string, count int, source Source) error {
+	return e.earnCapped(ctx, userID, name, count, 0, source)
 }
 
-func (e earn) Earn(ctx context.Context, name string, count int) error {
+// earnCapped earns at most what's left of dailyCap for points of the name earned today, zero means no limit.
+// The score is locked before points of today are summed, so concurrent earn of the user can't both pass the cap.
+func (e earn) earnCapped(ctx context.Context, userID int, name string, count int, dailyCap int, source Source) error {
+	err := Retry(ctx, func(ctx context.Context) error {
+		return events.Commit(ctx, func(ctx context.Context) error {
+			return e.repository.Transaction(ctx, func(ctx context.Context) error {
+				count, err := e.capCount(ctx, userID, name, count, dailyCap)
+				if err != nil || count == 0 {
+					return err
+				}
+
+				return e.earn(ctx, userID, name, count, source)
+			})
+		})
+	})
+
+	if errors.Is(err, rel.ErrUniqueConstraint) && source.DedupeKey != "" {
+		// the transaction is rolled back, so the score is left untouched.
+		logger.Info("duplicate point ignored", zap.Int("user_id", userID), zap.String("dedupe_key", source.DedupeKey))
+		return nil
+	}
+
+	return err
+}
+
+func (e earn) earn(ctx context.Context, userID int, name string, count int, source Source) error {
 	var (
 		score Score
+		now   = e.now()
 	)
 
-	return e.repository.Transaction(ctx, func(ctx context.Context) error {
-		// for simplicity, assumes only one user, so there's only one score and always retrieve the first one.
-		// this will probably lock the entire table since there's no where clause provided, but it's fine since we assume only one user.
-		if err := e.repository.Find(ctx, &score, rel.ForUpdate()); err != nil {
-			if !errors.Is(err, rel.ErrNotFound) {
-				// unexpected error.
-				return err
-			}
-
-			score.TotalPoint = count
-			e.repository.MustInsert(ctx, &score)
-		} else {
-			score.TotalPoint += count
-			e.repository.Update(ctx, &score)
+	if err := e.increment(ctx, userID, count, now); err != nil {
+		return err
+	}
+
+	advanced, err := e.advanceStreak(ctx, userID, name, count, now)
+	if err != nil {
+		return err
+	}
+
+	// reads the score updated within this transaction.
+	if err := e.repository.Find(ctx, &score, where.Eq("user_id", userID)); err != nil {
+		return err
+	}
+
+	// level before this earn is derived from the score, since the score is locked until the transaction ends.
+	previousLevel, _, _ := e.levels.level(score.TotalPoint - count)
+
+	// insert point history, fails when the dedupe key is already earned.
+	point := source.point(name, count)
+	point.ScoreID = score.ID
+	if err := e.repository.Insert(ctx, &point); err != nil {
+		return err
+	}
+
+	if milestone, ok := e.streak.milestone(score.CurrentStreak); advanced && ok {
+		if err := e.increment(ctx, userID, milestone.Points, now); err != nil {
+			return err
 		}
 
-		// insert point history.
-		e.repository.MustInsert(ctx, &Point{Name: name, Count: count, ScoreID: score.ID})
-		return nil
-	})
+		score.TotalPoint += milestone.Points
+		e.repository.MustInsert(ctx, &Point{Name: milestone.Name(), Count: milestone.Points, ScoreID: score.ID})
+	}
+
+	e.levels.apply(&score)
+
+	for _, listener := range e.listeners {
+		if err := listener.Earned(ctx, score); err != nil {
+			return err
+		}
+	}
+
+	// earned point is published within the transaction, handlers that must fail the earn with their error are listeners instead.
+	e.publisher.Publish(ctx, events.Event{Name: EventEarned, UserID: userID, Data: point})
+	if score.Level > previousLevel {
+		events.AfterCommit(ctx, e.publisher, events.Event{Name: EventLevelUp, UserID: userID, Data: LevelUp{From: previousLevel, To: score.Level}})
+	}
+
+	events.AfterCommit(ctx, e.publisher, events.Event{Name: EventChanged, UserID: userID, Data: score})
+
+	return nil
+}
+
+// capCount to what's left of dailyCap, the score stays locked until the transaction ends.
+func (e earn) capCount(ctx context.Context, userID int, name string, count int, dailyCap int) (int, error) {
+	if dailyCap <= 0 || count <= 0 {
+		return count, nil
+	}
+
+	if err := e.lock(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	earned, err := e.earnedToday(ctx, userID, name)
+	if err != nil {
+		return 0, err
+	}
+
+	return min(count, max(dailyCap-earned, 0)), nil
+}
+
+// lock score of the user for update, the first score of a user is inserted when it doesn't exist yet.
+func (e earn) lock(ctx context.Context, userID int) error {
+	var score Score
+	err := e.repository.Find(ctx, &score, where.Eq("user_id", userID), rel.ForUpdate())
+	if !errors.Is(err, rel.ErrNotFound) {
+		return err
+	}
+
+	// concurrent earn may insert the same score, the lock is taken by whichever read comes first.
+	if err := e.repository.Insert(ctx, &Score{UserID: userID}, rel.OnConflictIgnore()); err != nil {
+		return err
+	}
+
+	return e.repository.Find(ctx, &score, where.Eq("user_id", userID), rel.ForUpdate())
+}
+
+// earnedToday sums points earned by user from the rule since start of the day, days start at midnight UTC.
+func (e earn) earnedToday(ctx context.Context, userID int, name string) (int, error) {
+	var (
+		now   = e.now().UTC()
+		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		query = rel.From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Where(where.Eq("scores.user_id", userID), where.Eq("points.name", name), where.Gte("points.created_at", today))
+	)
+
+	return e.repository.Aggregate(ctx, query, "sum", "points.count")
+}
+
+// increment total point atomically, the first score of a user is inserted when it doesn't exist yet.
+func (e earn) increment(ctx context.Context, userID int, count int, now time.Time) error {
+	var (
+		query   = rel.From("scores").Where(where.Eq("user_id", userID))
+		mutates = []rel.Mutate{rel.IncBy("total_point", count), rel.Set("updated_at", now)}
+	)
+
+	if updated, err := e.repository.UpdateAny(ctx, query, mutates...); err != nil || updated > 0 {
+		return err
+	}
+
+	// concurrent earn may insert the same score, which is fine since only the increment matters.
+	if err := e.repository.Insert(ctx, &Score{UserID: userID}, rel.OnConflictIgnore()); err != nil {
+		return err
+	}
+
+	_, err := e.repository.UpdateAny(ctx, query, mutates...)
+	return err
+}
+
+// advanceStreak returns true if the streak is advanced by this earn, which only happens on the first completion of the day.
+func (e earn) advanceStreak(ctx context.Context, userID int, name string, count int, now time.Time) (bool, error) {
+	if !e.streak.counts(name, count) {
+		return false, nil
+	}
+
+	var (
+		today     = e.streak.day(now, 0)
+		yesterday = e.streak.day(now, -1)
+	)
+
+	_, updated, err := e.repository.Exec(ctx, streakQuery, yesterday, today, userID, today)
+	return updated > 0, err
 }
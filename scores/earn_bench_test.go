@@ -0,0 +1,112 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/mysql"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// benchmarkUsers shares scores between goroutines, so writers contend on the same rows.
+const benchmarkUsers = 8
+
+// lockingEarn is the previous earn implementation, which locks the score before updating it.
+type lockingEarn struct {
+	repository rel.Repository
+}
+
+func (e lockingEarn) Earn(ctx context.Context, userID int, name string, count int, source Source) error {
+	var (
+		score Score
+	)
+
+	return e.repository.Transaction(ctx, func(ctx context.Context) error {
+		if err := e.repository.Find(ctx, &score, where.Eq("user_id", userID), rel.ForUpdate()); err != nil {
+			if !errors.Is(err, rel.ErrNotFound) {
+				return err
+			}
+
+			score.UserID = userID
+		}
+
+		score.TotalPoint += count
+		if score.ID == 0 {
+			if err := e.repository.Insert(ctx, &score); err != nil {
+				return err
+			}
+		} else if err := e.repository.Update(ctx, &score); err != nil {
+			return err
+		}
+
+		return e.repository.Insert(ctx, &Point{Name: name, Count: count, ScoreID: score.ID})
+	})
+}
+
+// BenchmarkEarn compares concurrent throughput of atomic and locking earn, it requires a migrated mysql database:
+//
+//	export $(cat .env | grep -v ^\# | xargs) && go test ./scores -run - -bench Earn -cpu 1,8,32
+func BenchmarkEarn(b *testing.B) {
+	if os.Getenv("MYSQL_HOST") == "" {
+		b.Skip("MYSQL_HOST is not set")
+	}
+
+	dsn := fmt.Sprintf("%s:%s@(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		os.Getenv("MYSQL_USERNAME"),
+		os.Getenv("MYSQL_PASSWORD"),
+		os.Getenv("MYSQL_HOST"),
+		os.Getenv("MYSQL_PORT"),
+		os.Getenv("MYSQL_DATABASE"))
+
+	adapter, err := mysql.Open(dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer adapter.Close()
+
+	var (
+		repository = rel.New(adapter)
+		services   = []struct {
+			name string
+			earn interface {
+				Earn(ctx context.Context, userID int, name string, count int, source Source) error
+			}
+		}{
+			{name: "atomic", earn: New(repository, DefaultConfig, events.Nop{})},
+			{name: "locking", earn: lockingEarn{repository: repository}},
+		}
+	)
+
+	b.Cleanup(func() {
+		ctx := context.Background()
+		repository.MustDeleteAny(ctx, rel.From("points").Where(where.Eq("name", "benchmark")))
+		repository.MustDeleteAny(ctx, rel.From("scores").Where(where.Gte("user_id", 1000000)))
+	})
+
+	for i, service := range services {
+		b.Run(service.name, func(b *testing.B) {
+			var (
+				ctx  = context.Background()
+				next int64
+				// separate users per strategy, so both start from the same state.
+				offset = 1000000 + i*benchmarkUsers
+			)
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					userID := offset + int(atomic.AddInt64(&next, 1)%benchmarkUsers)
+					if err := service.earn.Earn(ctx, userID, "benchmark", 1, Source{}); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
+}
@@ -2,29 +2,61 @@ package scores
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 )
 
+// Wednesday, 14 October 2026 20:00 UTC, which is already 15 October in Asia/Jakarta.
+var earnNow = time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC)
+
+func newEarnService(repository *reltest.Repository) service {
+	service := New(repository, Config{
+		Rules: DefaultRules,
+		Streak: Streak{
+			TimeZone:   "Asia/Jakarta",
+			Names:      []string{"todo completed"},
+			Milestones: []Milestone{{Days: 3, Points: 5}},
+		},
+		Levels: Levels{Curve: CurveLinear, Base: 10},
+	}, events.Nop{}).(service)
+	service.earn.now = func() time.Time { return earnNow }
+	return service
+}
+
+func expectIncrement(repository *reltest.Repository, userID int, count int) *reltest.MockUpdateAny {
+	return repository.ExpectUpdateAny(rel.From("scores").Where(where.Eq("user_id", userID)), rel.IncBy("total_point", count), rel.Set("updated_at", earnNow))
+}
+
+func expectStreak(repository *reltest.Repository, userID int) *reltest.MockExec {
+	// reltest records exec arguments as a single slice.
+	return repository.ExpectExec(streakQuery, []any{"2026-10-14", "2026-10-15", userID, "2026-10-15"})
+}
+
 func TestEarn(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository)
+		service    = newEarnService(repository)
+		userID     = 1
 		name       = "todo completed"
 		count      = 1
 	)
 
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		repository.ExpectFind(rel.ForUpdate()).Result(Score{ID: 1, TotalPoint: 10})
-		repository.ExpectUpdate().For(&Score{ID: 1, TotalPoint: 11})
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 1)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 11, CurrentStreak: 1, LongestStreak: 1, StreakOn: "2026-10-15"})
 		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1})
 	})
 
-	assert.Nil(t, service.Earn(ctx, name, count))
+	assert.Nil(t, service.Earn(ctx, userID, name, count, Source{}))
 	repository.AssertExpectations(t)
 }
 
@@ -32,18 +64,147 @@ func TestEarn_insertScore(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository)
+		service    = newEarnService(repository)
+		userID     = 1
 		name       = "todo completed"
 		count      = 1
 	)
 
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		repository.ExpectFind(rel.ForUpdate()).NotFound()
-		repository.ExpectInsert().For(&Score{TotalPoint: 1})
+		expectIncrement(repository, userID, count).UpdatedCount(0)
+		repository.ExpectInsert(rel.OnConflictIgnore()).For(&Score{UserID: userID})
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 1)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 1, CurrentStreak: 1, LongestStreak: 1, StreakOn: "2026-10-15"})
 		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1})
 	})
 
-	assert.Nil(t, service.Earn(ctx, name, count))
+	assert.Nil(t, service.Earn(ctx, userID, name, count, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_streak(t *testing.T) {
+	tests := []struct {
+		name     string
+		point    string
+		count    int
+		advanced bool
+		score    Score
+		bonus    bool
+	}{
+		{
+			name:  "same day",
+			point: "todo completed",
+			count: 1,
+			score: Score{ID: 1, UserID: 1, TotalPoint: 11, CurrentStreak: 3, LongestStreak: 4, StreakOn: "2026-10-15"},
+		},
+		{
+			name:     "continued",
+			point:    "todo completed",
+			count:    1,
+			advanced: true,
+			score:    Score{ID: 1, UserID: 1, TotalPoint: 11, CurrentStreak: 2, LongestStreak: 4, StreakOn: "2026-10-15"},
+		},
+		{
+			name:     "milestone",
+			point:    "todo completed",
+			count:    1,
+			advanced: true,
+			score:    Score{ID: 1, UserID: 1, TotalPoint: 11, CurrentStreak: 3, LongestStreak: 3, StreakOn: "2026-10-15"},
+			bonus:    true,
+		},
+		{
+			name:  "not a completion",
+			point: "todo uncompleted",
+			count: -2,
+			score: Score{ID: 1, UserID: 1, TotalPoint: 8, CurrentStreak: 3, LongestStreak: 3, StreakOn: "2026-10-13"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = newEarnService(repository)
+			)
+
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				expectIncrement(repository, 1, test.count).UpdatedCount(1)
+				if test.count > 0 {
+					updated := 0
+					if test.advanced {
+						updated = 1
+					}
+					expectStreak(repository, 1).Result(0, updated)
+				}
+				repository.ExpectFind(where.Eq("user_id", 1)).Result(test.score)
+				repository.ExpectInsert().For(&Point{Name: test.point, Count: test.count, ScoreID: 1})
+				if test.bonus {
+					expectIncrement(repository, 1, 5).UpdatedCount(1)
+					repository.ExpectInsert().For(&Point{Name: "streak 3 days", Count: 5, ScoreID: 1})
+				}
+			})
+
+			assert.Nil(t, service.Earn(ctx, 1, test.point, test.count, Source{}))
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEarn_incrementError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Earn(ctx, userID, name, count, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_insertScoreError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(0)
+		repository.ExpectInsert(rel.OnConflictIgnore()).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Earn(ctx, userID, name, count, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_streakError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Earn(ctx, userID, name, count, Source{}))
 	repository.AssertExpectations(t)
 }
 
@@ -51,16 +212,260 @@ func TestEarn_findError(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository)
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", userID)).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Earn(ctx, userID, name, count, Source{}))
+	repository.AssertExpectations(t)
+}
+
+type listenerFunc func(ctx context.Context, score Score) error
+
+func (f listenerFunc) Earned(ctx context.Context, score Score) error {
+	return f(ctx, score)
+}
+
+func TestEarn_listener(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
 		name       = "todo completed"
 		count      = 1
+		earned     []int
 	)
 
+	service.earn.listeners = []Listener{listenerFunc(func(ctx context.Context, score Score) error {
+		earned = append(earned, score.TotalPoint)
+		return nil
+	})}
+
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		repository.ExpectFind(rel.ForUpdate()).ConnectionClosed()
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 11})
+		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1})
 	})
 
-	assert.Equal(t, reltest.ErrConnectionClosed, service.Earn(ctx, name, count))
+	assert.Nil(t, service.Earn(ctx, userID, name, count, Source{}))
+	assert.Equal(t, []int{11}, earned)
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_published(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		bus        = events.NewBus()
+		published  []events.Event
+	)
+
+	service.earn.publisher = bus
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		// timestamps are set by the repository.
+		if point, ok := event.Data.(Point); ok {
+			point.CreatedAt, point.UpdatedAt = time.Time{}, time.Time{}
+			event.Data = point
+		}
+
+		event.At = earnNow
+		published = append(published, event)
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, 1, 1).UpdatedCount(1)
+		expectStreak(repository, 1).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 12})
+		repository.ExpectInsert().For(&Point{Name: "todo completed", Count: 1, ScoreID: 1})
+	})
+
+	assert.Nil(t, service.Earn(ctx, 1, "todo completed", 1, Source{}))
+	assert.Equal(t, []events.Event{
+		{Name: EventEarned, UserID: 1, Data: Point{ID: 1, Name: "todo completed", Count: 1, ScoreID: 1}, At: earnNow},
+		{Name: EventChanged, UserID: 1, Data: Score{ID: 1, UserID: 1, TotalPoint: 12, Level: 2, LevelProgress: 2, NextLevelAt: 20}, At: earnNow},
+	}, published)
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_levelUp(t *testing.T) {
+	tests := []struct {
+		name       string
+		totalPoint int
+		events     []events.Event
+	}{
+		{
+			name:       "level up",
+			totalPoint: 21,
+			events:     []events.Event{{Name: EventLevelUp, UserID: 1, Data: LevelUp{From: 2, To: 3}, At: earnNow}},
+		},
+		{
+			name:       "same level",
+			totalPoint: 22,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = newEarnService(repository)
+				bus        = events.NewBus()
+				published  []events.Event
+			)
+
+			service.earn.publisher = bus
+			bus.Subscribe(func(ctx context.Context, event events.Event) {
+				if event.Name == EventLevelUp {
+					event.At = earnNow
+					published = append(published, event)
+				}
+			})
+
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				expectIncrement(repository, 1, 2).UpdatedCount(1)
+				expectStreak(repository, 1).Result(0, 0)
+				repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: test.totalPoint})
+				repository.ExpectInsert().For(&Point{Name: "todo completed", Count: 2, ScoreID: 1})
+			})
+
+			assert.Nil(t, service.Earn(ctx, 1, "todo completed", 2, Source{}))
+			assert.Equal(t, test.events, published)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEarn_levelUpRolledBack(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		bus        = events.NewBus()
+		err        = errors.New("outer transaction error")
+		published  []string
+	)
+
+	service.earn.publisher = bus
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		published = append(published, event.Name)
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, 1, 2).UpdatedCount(1)
+		expectStreak(repository, 1).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 21})
+		repository.ExpectInsert().For(&Point{Name: "todo completed", Count: 2, ScoreID: 1})
+	})
+
+	// earn within a transaction that's rolled back afterward only publishes the earned point.
+	assert.Equal(t, err, events.Commit(ctx, func(ctx context.Context) error {
+		assert.Nil(t, service.Earn(ctx, 1, "todo completed", 2, Source{}))
+		return err
+	}))
+	assert.Equal(t, []string{EventEarned}, published)
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_listenerError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+		err        = errors.New("listener error")
+	)
+
+	service.earn.listeners = []Listener{listenerFunc(func(ctx context.Context, score Score) error {
+		return err
+	})}
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 11})
+		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1})
+	})
+
+	assert.Equal(t, err, service.Earn(ctx, userID, name, count, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_source(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+		dedupeKey  = "todo completed:todo:2"
+		source     = Source{Type: "todo", ID: 2, DedupeKey: dedupeKey}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 11})
+		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1, SourceType: "todo", SourceID: 2, DedupeKey: &dedupeKey})
+	})
+
+	assert.Nil(t, service.Earn(ctx, userID, name, count, source))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_duplicate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo completed"
+		count      = 1
+		dedupeKey  = "todo completed:todo:2"
+		source     = Source{Type: "todo", ID: 2, DedupeKey: dedupeKey}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		expectStreak(repository, userID).Result(0, 0)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 11})
+		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1, SourceType: "todo", SourceID: 2, DedupeKey: &dedupeKey}).NotUnique("score_id_dedupe_key")
+	})
+
+	assert.Nil(t, service.Earn(ctx, userID, name, count, source))
+	repository.AssertExpectations(t)
+}
+
+func TestEarn_duplicateWithoutDedupeKey(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newEarnService(repository)
+		userID     = 1
+		name       = "todo uncompleted"
+		count      = -2
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, userID, count).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", userID)).Result(Score{ID: 1, UserID: userID, TotalPoint: 9})
+		repository.ExpectInsert().For(&Point{Name: name, Count: count, ScoreID: 1}).NotUnique("PRIMARY")
+	})
 
+	assert.True(t, errors.Is(service.Earn(ctx, userID, name, count, Source{}), rel.ErrUniqueConstraint))
 	repository.AssertExpectations(t)
 }
@@ -0,0 +1,30 @@
+package scores
+
+import (
+	"context"
+)
+
+type evaluate struct {
+	earn  earn
+	rules Rules
+}
+
+// Evaluate event against every rule, points of a rule with daily cap are capped within the same transaction they're earned.
+func (e evaluate) Evaluate(ctx context.Context, event Event) error {
+	for _, rule := range e.rules {
+		if !rule.Match(event) {
+			continue
+		}
+
+		points := rule.Calculate(event)
+		if points == 0 {
+			continue
+		}
+
+		if err := e.earn.earnCapped(ctx, event.UserID, rule.Name, points, rule.DailyCap, rule.Source(event)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
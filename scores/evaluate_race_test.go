@@ -0,0 +1,68 @@
+package scores
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/mysql"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEvaluate_dailyCapRace completes simultaneously against a migrated mysql database, daily cap must never be exceeded:
+//
+//	export $(cat .env | grep -v ^\# | xargs) && go test ./scores -run TestEvaluate_dailyCapRace
+func TestEvaluate_dailyCapRace(t *testing.T) {
+	if os.Getenv("MYSQL_HOST") == "" {
+		t.Skip("MYSQL_HOST is not set")
+	}
+
+	adapter, err := mysql.Open(fmt.Sprintf("%s:%s@(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local",
+		os.Getenv("MYSQL_USERNAME"),
+		os.Getenv("MYSQL_PASSWORD"),
+		os.Getenv("MYSQL_HOST"),
+		os.Getenv("MYSQL_PORT"),
+		os.Getenv("MYSQL_DATABASE")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adapter.Close()
+
+	var (
+		ctx        = context.Background()
+		repository = rel.New(adapter)
+		rules      = Rules{{Name: "race completed", Event: "todo.completed", Points: 2, DailyCap: 5}}
+		service    = New(repository, Config{Rules: rules}, events.Nop{})
+		userID     = 2000001
+		attempts   = 10
+		wg         sync.WaitGroup
+	)
+
+	t.Cleanup(func() {
+		var score Score
+		if err := repository.Find(ctx, &score, where.Eq("user_id", userID)); err == nil {
+			repository.MustDeleteAny(ctx, rel.From("points").Where(where.Eq("score_id", score.ID)))
+			repository.MustDeleteAny(ctx, rel.From("scores").Where(where.Eq("id", score.ID)))
+		}
+	})
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, service.Evaluate(ctx, Event{Name: "todo.completed", UserID: userID}))
+		}()
+	}
+
+	wg.Wait()
+
+	var score Score
+	repository.MustFind(ctx, &score, where.Eq("user_id", userID))
+	assert.Equal(t, 5, score.TotalPoint)
+}
@@ -0,0 +1,186 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{}).(service)
+		dedupeKey  = "todo uncompleted:todo:2"
+	)
+
+	service.evaluate.earn.now = func() time.Time { return earnNow }
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectIncrement(repository, 1, -2).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 8})
+		repository.ExpectInsert().For(&Point{Name: "todo uncompleted", Count: -2, ScoreID: 1, SourceType: "todo", SourceID: 2, DedupeKey: &dedupeKey})
+	})
+
+	assert.Nil(t, service.Evaluate(ctx, Event{Name: "todo.uncompleted", UserID: 1, Source: Source{Type: "todo", ID: 2}}))
+	repository.AssertExpectations(t)
+}
+
+func TestEvaluate_noMatch(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	assert.Nil(t, service.Evaluate(ctx, Event{Name: "todo.deleted", UserID: 1}))
+	repository.AssertExpectations(t)
+}
+
+func TestEvaluate_dailyCap(t *testing.T) {
+	var (
+		today = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		high  = 3
+		rules = Rules{{
+			Name:        "todo completed",
+			Event:       "todo.completed",
+			Points:      2,
+			Multipliers: []Multiplier{{Conditions: Conditions{Priority: &high}, Factor: 2}},
+			DailyCap:    10,
+		}}
+		query = rel.From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Where(where.Eq("scores.user_id", 1), where.Eq("points.name", "todo completed"), where.Gte("points.created_at", today))
+	)
+
+	tests := []struct {
+		name   string
+		earned int
+		event  Event
+		count  int
+	}{
+		{
+			name:   "below cap",
+			earned: 2,
+			event:  Event{Name: "todo.completed", UserID: 1, Priority: 3},
+			count:  4,
+		},
+		{
+			name:   "capped",
+			earned: 9,
+			event:  Event{Name: "todo.completed", UserID: 1, Priority: 3},
+			count:  1,
+		},
+		{
+			name:   "cap reached",
+			earned: 10,
+			event:  Event{Name: "todo.completed", UserID: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = New(repository, Config{Rules: rules}, events.Nop{}).(service)
+			)
+
+			service.evaluate.earn.now = func() time.Time { return earnNow }
+
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				// points of today are summed once the score is locked.
+				repository.ExpectFind(where.Eq("user_id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 1, TotalPoint: 10})
+				repository.ExpectAggregate(query, "sum", "points.count").Result(test.earned)
+				if test.count != 0 {
+					expectIncrement(repository, 1, test.count).UpdatedCount(1)
+					repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 10 + test.count})
+					repository.ExpectInsert().For(&Point{Name: "todo completed", Count: test.count, ScoreID: 1})
+				}
+			})
+
+			assert.Nil(t, service.Evaluate(ctx, test.event))
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEvaluate_dailyCapUTC(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, Config{Rules: Rules{{Name: "todo completed", Event: "todo.completed", Points: 1, DailyCap: 5}}}, events.Nop{}).(service)
+		// it's already the next day in the local time zone, but not in UTC.
+		now   = earnNow.In(time.FixedZone("UTC+9", 9*60*60))
+		today = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		query = rel.From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Where(where.Eq("scores.user_id", 1), where.Eq("points.name", "todo completed"), where.Gte("points.created_at", today))
+	)
+
+	service.evaluate.earn.now = func() time.Time { return now }
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(where.Eq("user_id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 1, TotalPoint: 5})
+		repository.ExpectAggregate(query, "sum", "points.count").Result(5)
+	})
+
+	assert.Nil(t, service.Evaluate(ctx, Event{Name: "todo.completed", UserID: 1}))
+	repository.AssertExpectations(t)
+}
+
+func TestEvaluate_dailyCapNewScore(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, Config{Rules: Rules{{Name: "todo completed", Event: "todo.completed", Points: 1, DailyCap: 5}}}, events.Nop{}).(service)
+		today      = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		query      = rel.From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1), where.Eq("points.name", "todo completed"), where.Gte("points.created_at", today))
+	)
+
+	service.evaluate.earn.now = func() time.Time { return earnNow }
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		// score is inserted before it's locked, so the first earn of concurrent ones still waits for the lock.
+		repository.ExpectFind(where.Eq("user_id", 1), rel.ForUpdate()).NotFound()
+		repository.ExpectInsert(rel.OnConflictIgnore()).For(&Score{UserID: 1})
+		repository.ExpectFind(where.Eq("user_id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 1})
+		repository.ExpectAggregate(query, "sum", "points.count").Result(0)
+		expectIncrement(repository, 1, 1).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 1})
+		repository.ExpectInsert().For(&Point{Name: "todo completed", Count: 1, ScoreID: 1})
+	})
+
+	assert.Nil(t, service.Evaluate(ctx, Event{Name: "todo.completed", UserID: 1}))
+	repository.AssertExpectations(t)
+}
+
+func TestEvaluate_aggregateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, Config{Rules: Rules{{Name: "todo completed", Event: "todo.completed", Points: 1, DailyCap: 5}}}, events.Nop{}).(service)
+		today      = time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+		query      = rel.From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1), where.Eq("points.name", "todo completed"), where.Gte("points.created_at", today))
+	)
+
+	service.evaluate.earn.now = func() time.Time { return earnNow }
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(where.Eq("user_id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 1})
+		repository.ExpectAggregate(query, "sum", "points.count").ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Evaluate(ctx, Event{Name: "todo.completed", UserID: 1}))
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type find struct {
+	repository rel.Repository
+	streak     Streak
+	levels     Levels
+	now        func() time.Time
+}
+
+func (f find) Find(ctx context.Context, score *Score, userID int) error {
+	if err := f.repository.Find(ctx, score, where.Eq("user_id", userID)); err != nil {
+		if !errors.Is(err, rel.ErrNotFound) {
+			return err
+		}
+
+		// user without any point yet have an empty score.
+		*score = Score{UserID: userID}
+	}
+
+	f.streak.refresh(score, f.now())
+	f.levels.apply(score)
+	return nil
+}
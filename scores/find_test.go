@@ -0,0 +1,74 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   func(repository *reltest.Repository)
+		result Score
+	}{
+		{
+			name: "active streak",
+			mock: func(repository *reltest.Repository) {
+				repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 10, CurrentStreak: 2, LongestStreak: 3, StreakOn: "2026-10-14"})
+			},
+			result: Score{ID: 1, UserID: 1, TotalPoint: 10, CurrentStreak: 2, LongestStreak: 3, StreakOn: "2026-10-14", Level: 2, NextLevelAt: 20},
+		},
+		{
+			name: "broken streak",
+			mock: func(repository *reltest.Repository) {
+				repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 1, UserID: 1, TotalPoint: 10, CurrentStreak: 2, LongestStreak: 3, StreakOn: "2026-10-13"})
+			},
+			result: Score{ID: 1, UserID: 1, TotalPoint: 10, CurrentStreak: 0, LongestStreak: 3, StreakOn: "2026-10-13", Level: 2, NextLevelAt: 20},
+		},
+		{
+			name: "not found",
+			mock: func(repository *reltest.Repository) {
+				repository.ExpectFind(where.Eq("user_id", 1)).NotFound()
+			},
+			result: Score{UserID: 1, Level: 1, NextLevelAt: 10},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = newEarnService(repository)
+				score      Score
+			)
+
+			service.find.now = func() time.Time { return earnNow }
+			test.mock(repository)
+
+			assert.Nil(t, service.Find(ctx, &score, 1))
+			assert.Equal(t, test.result, score)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFind_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		score      Score
+	)
+
+	repository.ExpectFind(where.Eq("user_id", 1)).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Find(ctx, &score, 1))
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,164 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+// Available leaderboard windows.
+const (
+	WindowDay   = "day"
+	WindowWeek  = "week"
+	WindowMonth = "month"
+	WindowAll   = "all"
+)
+
+var (
+	// ErrLeaderboardWindowInvalid error.
+	ErrLeaderboardWindowInvalid = errors.New("Window must be one of day, week, month or all")
+	// DefaultLeaderboardLimit used when limit is not specified.
+	DefaultLeaderboardLimit = 10
+	// MaxLeaderboardLimit is the maximum number of entries in a leaderboard.
+	MaxLeaderboardLimit = 100
+)
+
+// LeaderboardEntry is a ranked user.
+type LeaderboardEntry struct {
+	Rank   int `json:"rank" db:"-"`
+	UserID int `json:"user_id"`
+	Points int `json:"points"`
+}
+
+// Leaderboard ranks users by points earned within the window.
+type Leaderboard struct {
+	Window  string             `json:"window"`
+	Since   *time.Time         `json:"since"`
+	Entries []LeaderboardEntry `json:"entries"`
+	// Me is the caller's entry, always present even when outside of entries.
+	Me LeaderboardEntry `json:"me"`
+}
+
+type rankCount struct {
+	Count int
+}
+
+type leaderboard struct {
+	repository rel.Repository
+	now        func() time.Time
+}
+
+func (l leaderboard) Leaderboard(ctx context.Context, userID int, window string, limit int) (Leaderboard, error) {
+	var (
+		result = Leaderboard{Window: window, Entries: []LeaderboardEntry{}}
+		err    error
+	)
+
+	if result.Since, err = l.since(window); err != nil {
+		return result, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultLeaderboardLimit
+	} else if limit > MaxLeaderboardLimit {
+		limit = MaxLeaderboardLimit
+	}
+
+	// ties are broken by user id, so the rank is deterministic.
+	query := l.ranking(result.Since).SortDesc("points").SortAsc("scores.user_id").Limit(limit)
+	if err := l.repository.FindAll(ctx, &result.Entries, query); err != nil {
+		return result, err
+	}
+
+	for i := range result.Entries {
+		result.Entries[i].Rank = i + 1
+		if result.Entries[i].UserID == userID {
+			result.Me = result.Entries[i]
+		}
+	}
+
+	if result.Me.Rank == 0 {
+		result.Me, err = l.rank(ctx, userID, result.Since)
+	}
+
+	return result, err
+}
+
+// ranking query that sums points per user.
+func (l leaderboard) ranking(since *time.Time) rel.Query {
+	query := rel.Select("scores.user_id", "SUM(points.count) AS points").
+		From("points").
+		JoinOn("scores", "scores.id", "points.score_id").
+		Group("scores.user_id")
+
+	if since != nil {
+		query = query.Where(where.Gte("points.created_at", *since))
+	}
+
+	return query
+}
+
+// rank of a single user, computed by counting users with higher points.
+func (l leaderboard) rank(ctx context.Context, userID int, since *time.Time) (LeaderboardEntry, error) {
+	var (
+		entry = LeaderboardEntry{UserID: userID}
+		count rankCount
+		query = rel.From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Where(where.Eq("scores.user_id", userID))
+		filter = ""
+		args   []interface{}
+		err    error
+	)
+
+	if since != nil {
+		query = query.Where(where.Gte("points.created_at", *since))
+		filter = "WHERE points.created_at >= ? "
+		args = append(args, *since)
+	}
+
+	if entry.Points, err = l.repository.Aggregate(ctx, query, "sum", "points.count"); err != nil {
+		return entry, err
+	}
+
+	args = append(args, entry.Points, entry.Points, userID)
+	if err := l.repository.Find(ctx, &count, rel.SQL("SELECT COUNT(*) AS count FROM ("+
+		"SELECT scores.user_id, SUM(points.count) AS points FROM points "+
+		"JOIN scores ON scores.id = points.score_id "+filter+
+		"GROUP BY scores.user_id "+
+		"HAVING points > ? OR (points = ? AND scores.user_id < ?)"+
+		") ranked", args...)); err != nil {
+		return entry, err
+	}
+
+	entry.Rank = count.Count + 1
+	return entry, nil
+}
+
+// since returns the start of the window, nil means no limit.
+func (l leaderboard) since(window string) (*time.Time, error) {
+	var (
+		now   = l.now()
+		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		since time.Time
+	)
+
+	switch window {
+	case WindowDay:
+		since = today
+	case WindowWeek:
+		// weeks start on monday.
+		since = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	case WindowMonth:
+		since = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	case WindowAll:
+		return nil, nil
+	default:
+		return nil, ErrLeaderboardWindowInvalid
+	}
+
+	return &since, nil
+}
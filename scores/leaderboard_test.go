@@ -0,0 +1,139 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Wednesday, 14 October 2026 15:04 UTC.
+var leaderboardNow = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+
+func newLeaderboardService(repository *reltest.Repository) service {
+	service := New(repository, DefaultConfig, events.Nop{}).(service)
+	service.leaderboard.now = func() time.Time { return leaderboardNow }
+	return service
+}
+
+func TestLeaderboard(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newLeaderboardService(repository)
+		since      = time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+		entries    = []LeaderboardEntry{{UserID: 2, Points: 10}, {UserID: 1, Points: 10}, {UserID: 3, Points: 4}}
+	)
+
+	repository.ExpectFindAll(
+		rel.Select("scores.user_id", "SUM(points.count) AS points").
+			From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Group("scores.user_id").
+			Where(where.Gte("points.created_at", since)).
+			SortDesc("points").SortAsc("scores.user_id").Limit(3),
+	).Result(entries)
+
+	result, err := service.Leaderboard(ctx, 1, WindowWeek, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, Leaderboard{
+		Window: WindowWeek,
+		Since:  &since,
+		Entries: []LeaderboardEntry{
+			{Rank: 1, UserID: 2, Points: 10},
+			{Rank: 2, UserID: 1, Points: 10},
+			{Rank: 3, UserID: 3, Points: 4},
+		},
+		Me: LeaderboardEntry{Rank: 2, UserID: 1, Points: 10},
+	}, result)
+
+	repository.AssertExpectations(t)
+}
+
+func TestLeaderboard_outsideTop(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newLeaderboardService(repository)
+	)
+
+	repository.ExpectFindAll(
+		rel.Select("scores.user_id", "SUM(points.count) AS points").
+			From("points").
+			JoinOn("scores", "scores.id", "points.score_id").
+			Group("scores.user_id").
+			SortDesc("points").SortAsc("scores.user_id").Limit(DefaultLeaderboardLimit),
+	).Result([]LeaderboardEntry{{UserID: 2, Points: 10}})
+	repository.ExpectAggregate(
+		rel.From("points").JoinOn("scores", "scores.id", "points.score_id").Where(where.Eq("scores.user_id", 5)),
+		"sum", "points.count",
+	).Result(3)
+	repository.ExpectFind(rel.SQL("SELECT COUNT(*) AS count FROM ("+
+		"SELECT scores.user_id, SUM(points.count) AS points FROM points "+
+		"JOIN scores ON scores.id = points.score_id "+
+		"GROUP BY scores.user_id "+
+		"HAVING points > ? OR (points = ? AND scores.user_id < ?)"+
+		") ranked", 3, 3, 5)).Result(rankCount{Count: 14})
+
+	result, err := service.Leaderboard(ctx, 5, WindowAll, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, Leaderboard{
+		Window:  WindowAll,
+		Entries: []LeaderboardEntry{{Rank: 1, UserID: 2, Points: 10}},
+		Me:      LeaderboardEntry{Rank: 15, UserID: 5, Points: 3},
+	}, result)
+
+	repository.AssertExpectations(t)
+}
+
+func TestLeaderboard_invalidWindow(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newLeaderboardService(repository)
+	)
+
+	_, err := service.Leaderboard(ctx, 1, "year", 10)
+	assert.Equal(t, ErrLeaderboardWindowInvalid, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestLeaderboard_since(t *testing.T) {
+	var (
+		l = leaderboard{now: func() time.Time { return leaderboardNow }}
+	)
+
+	tests := []struct {
+		window string
+		since  *time.Time
+	}{
+		{window: WindowDay, since: ptrTime(time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC))},
+		{window: WindowWeek, since: ptrTime(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC))},
+		{window: WindowMonth, since: ptrTime(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))},
+		{window: WindowAll},
+	}
+
+	for _, test := range tests {
+		t.Run(test.window, func(t *testing.T) {
+			since, err := l.since(test.window)
+			assert.Nil(t, err)
+			assert.Equal(t, test.since, since)
+		})
+	}
+
+	t.Run("sunday", func(t *testing.T) {
+		l := leaderboard{now: func() time.Time { return time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC) }}
+		since, _ := l.since(WindowWeek)
+		assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), *since)
+	})
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
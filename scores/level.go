@@ -0,0 +1,116 @@
+package scores
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// CurveLinear requires the same points for every level.
+	CurveLinear = "linear"
+	// CurveExponential multiplies points required for every next level by factor.
+	CurveExponential = "exponential"
+	// CurveTable uses explicit thresholds.
+	CurveTable = "table"
+)
+
+var (
+	// ErrLevelCurveInvalid validation error.
+	ErrLevelCurveInvalid = errors.New("Level curve must be one of linear, exponential or table")
+	// ErrLevelBaseInvalid validation error.
+	ErrLevelBaseInvalid = errors.New("Level base must be greater than zero")
+	// ErrLevelFactorInvalid validation error.
+	ErrLevelFactorInvalid = errors.New("Level factor must be greater than one")
+	// ErrLevelThresholdsInvalid validation error.
+	ErrLevelThresholdsInvalid = errors.New("Level thresholds must be positive and ascending")
+
+	// DefaultLevels used when no config file is configured.
+	DefaultLevels = Levels{
+		Curve:  CurveExponential,
+		Base:   10,
+		Factor: 1.5,
+	}
+)
+
+// Levels configuration, every user starts at level 1 with zero points.
+type Levels struct {
+	Curve string `json:"curve"`
+	// Base is points required to reach level 2.
+	Base   int     `json:"base"`
+	Factor float64 `json:"factor"`
+	// Thresholds are total points required to reach level 2, 3 and so on.
+	Thresholds []int `json:"thresholds"`
+}
+
+// LevelUp data of level up event.
+type LevelUp struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// Validate levels.
+func (l Levels) Validate() error {
+	switch l.Curve {
+	case CurveLinear:
+		if l.Base <= 0 {
+			return ErrLevelBaseInvalid
+		}
+	case CurveExponential:
+		if l.Base <= 0 {
+			return ErrLevelBaseInvalid
+		}
+
+		if l.Factor <= 1 {
+			return ErrLevelFactorInvalid
+		}
+	case CurveTable:
+		for i := range l.Thresholds {
+			if l.Thresholds[i] <= 0 || (i > 0 && l.Thresholds[i] <= l.Thresholds[i-1]) {
+				return ErrLevelThresholdsInvalid
+			}
+		}
+	default:
+		return ErrLevelCurveInvalid
+	}
+
+	return nil
+}
+
+// level reached by total point, along with total point required to reach the level and the next one.
+// next is zero when the highest level of the table is reached.
+func (l Levels) level(totalPoint int) (level int, at int, next int) {
+	switch {
+	case l.Curve == CurveLinear && l.Base > 0:
+		level = max(totalPoint, 0)/l.Base + 1
+		return level, (level - 1) * l.Base, level * l.Base
+	case l.Curve == CurveExponential && l.Base > 0 && l.Factor > 1:
+		step := float64(l.Base)
+		for level, next = 1, l.Base; totalPoint >= next; level++ {
+			step *= l.Factor
+			at, next = next, next+int(math.Round(step))
+		}
+
+		return level, at, next
+	case l.Curve == CurveTable:
+		for level = 1; level <= len(l.Thresholds) && totalPoint >= l.Thresholds[level-1]; level++ {
+			at = l.Thresholds[level-1]
+		}
+
+		if level <= len(l.Thresholds) {
+			next = l.Thresholds[level-1]
+		}
+
+		return level, at, next
+	default:
+		return 1, 0, 0
+	}
+}
+
+// apply level of total point to score.
+func (l Levels) apply(score *Score) {
+	level, at, next := l.level(score.TotalPoint)
+
+	score.Level = level
+	score.LevelProgress = max(score.TotalPoint-at, 0)
+	score.NextLevelAt = next
+}
@@ -0,0 +1,95 @@
+package scores
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels_Validate(t *testing.T) {
+	assert.Nil(t, DefaultLevels.Validate())
+	assert.Nil(t, Levels{Curve: CurveLinear, Base: 10}.Validate())
+	assert.Nil(t, Levels{Curve: CurveTable, Thresholds: []int{10, 30}}.Validate())
+	assert.Equal(t, ErrLevelCurveInvalid, Levels{Curve: "log"}.Validate())
+	assert.Equal(t, ErrLevelBaseInvalid, Levels{Curve: CurveLinear}.Validate())
+	assert.Equal(t, ErrLevelBaseInvalid, Levels{Curve: CurveExponential, Factor: 2}.Validate())
+	assert.Equal(t, ErrLevelFactorInvalid, Levels{Curve: CurveExponential, Base: 10, Factor: 1}.Validate())
+	assert.Equal(t, ErrLevelThresholdsInvalid, Levels{Curve: CurveTable, Thresholds: []int{0, 10}}.Validate())
+	assert.Equal(t, ErrLevelThresholdsInvalid, Levels{Curve: CurveTable, Thresholds: []int{30, 10}}.Validate())
+}
+
+func TestLevels_apply(t *testing.T) {
+	var (
+		linear      = Levels{Curve: CurveLinear, Base: 10}
+		exponential = Levels{Curve: CurveExponential, Base: 10, Factor: 1.5}
+		table       = Levels{Curve: CurveTable, Thresholds: []int{10, 30, 60}}
+	)
+
+	tests := []struct {
+		name       string
+		levels     Levels
+		totalPoint int
+		result     Score
+	}{
+		{
+			name:   "linear zero",
+			levels: linear,
+			result: Score{Level: 1, NextLevelAt: 10},
+		},
+		{
+			name:       "linear",
+			levels:     linear,
+			totalPoint: 64,
+			result:     Score{TotalPoint: 64, Level: 7, LevelProgress: 4, NextLevelAt: 70},
+		},
+		{
+			name:       "linear negative",
+			levels:     linear,
+			totalPoint: -5,
+			result:     Score{TotalPoint: -5, Level: 1, NextLevelAt: 10},
+		},
+		{
+			name:       "exponential",
+			levels:     exponential,
+			totalPoint: 9,
+			result:     Score{TotalPoint: 9, Level: 1, LevelProgress: 9, NextLevelAt: 10},
+		},
+		{
+			name:       "exponential threshold",
+			levels:     exponential,
+			totalPoint: 25,
+			result:     Score{TotalPoint: 25, Level: 3, NextLevelAt: 48},
+		},
+		{
+			name:       "exponential high",
+			levels:     exponential,
+			totalPoint: 50,
+			result:     Score{TotalPoint: 50, Level: 4, LevelProgress: 2, NextLevelAt: 82},
+		},
+		{
+			name:       "table",
+			levels:     table,
+			totalPoint: 35,
+			result:     Score{TotalPoint: 35, Level: 3, LevelProgress: 5, NextLevelAt: 60},
+		},
+		{
+			name:       "table highest level",
+			levels:     table,
+			totalPoint: 100,
+			result:     Score{TotalPoint: 100, Level: 4, LevelProgress: 40},
+		},
+		{
+			name:       "not configured",
+			totalPoint: 5,
+			result:     Score{TotalPoint: 5, Level: 1, LevelProgress: 5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score := Score{TotalPoint: test.totalPoint}
+			test.levels.apply(&score)
+			assert.Equal(t, test.result, score)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package scores
+
+import (
+	"context"
+)
+
+// Events published by scores.
+const (
+	// EventEarned is published every time a user earns points, with the earned point as the data.
+	EventEarned = "score.earned"
+	// EventLevelUp is published when earned points reach the next level.
+	EventLevelUp = "score.level_up"
+	// EventChanged is published after points are earned or spent and committed, with the updated score as the data.
+	EventChanged = "score.changed"
+)
+
+// Listener is notified after a user earns points, within the same transaction.
+// Returning an error rolls back the earned points.
+type Listener interface {
+	Earned(ctx context.Context, score Score) error
+}
@@ -6,10 +6,34 @@ import (
 
 // Point component for score.
 type Point struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Count     int       `json:"count"`
-	ScoreID   int       `json:"score_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Count      int    `json:"count"`
+	ScoreID    int    `json:"score_id"`
+	SourceType string `json:"source_type"`
+	SourceID   int    `json:"source_id"`
+	// DedupeKey is unique per score, nil when the point can be earned repeatedly.
+	DedupeKey *string `json:"dedupe_key,omitempty"`
+	// Adjustment is only loaded for adjusted points when listing points.
+	Adjustment *Adjustment `json:"adjustment,omitempty" db:"-"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// Source of points, such as the todo that earned them.
+type Source struct {
+	Type string
+	ID   int
+	// DedupeKey prevents the same points from being earned twice, empty means no dedupe.
+	DedupeKey string
+}
+
+// point earned from the source.
+func (s Source) point(name string, count int) Point {
+	point := Point{Name: name, Count: count, SourceType: s.Type, SourceID: s.ID}
+	if s.DedupeKey != "" {
+		point.DedupeKey = &s.DedupeKey
+	}
+
+	return point
 }
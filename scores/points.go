@@ -0,0 +1,169 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+// Available point aggregates.
+const (
+	AggregateDay  = "day"
+	AggregateWeek = "week"
+)
+
+var (
+	// ErrPointAggregateInvalid error.
+	ErrPointAggregateInvalid = errors.New("Aggregate must be one of day or week")
+	// ErrPointRangeInvalid error.
+	ErrPointRangeInvalid = errors.New("From must be before to")
+	// DefaultPointLimit used when limit is not specified.
+	DefaultPointLimit = 50
+	// MaxPointLimit is the maximum number of points in a page.
+	MaxPointLimit = 100
+
+	// bucket expressions are not escaped, weeks start on monday.
+	pointBuckets = map[string]string{
+		AggregateDay:  "^DATE(points.created_at) AS bucket",
+		AggregateWeek: "^DATE(DATE_SUB(points.created_at, INTERVAL WEEKDAY(points.created_at) DAY)) AS bucket",
+	}
+)
+
+// PointFilter for points, zero value means no filter.
+type PointFilter struct {
+	UserID     int
+	Name       string
+	SourceType string
+	SourceID   int
+	// From is inclusive and To is exclusive.
+	From time.Time
+	To   time.Time
+	// Cursor is the id of the last point of previous page.
+	Cursor int
+	Limit  int
+	// Aggregate sums points per bucket instead of listing them, aggregated result is not paginated.
+	Aggregate string
+}
+
+// PointBucket is the sum of points earned within a day or a week.
+type PointBucket struct {
+	Bucket time.Time `json:"bucket"`
+	Count  int       `json:"count"`
+}
+
+// PointPage is a page of points ordered from the newest, or buckets when aggregated.
+type PointPage struct {
+	Points  []Point
+	Buckets []PointBucket
+	// NextCursor is the cursor of next page, zero on the last page.
+	NextCursor int
+}
+
+type points struct {
+	repository rel.Repository
+}
+
+func (p points) Points(ctx context.Context, filter PointFilter) (PointPage, error) {
+	var (
+		result = PointPage{}
+	)
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return result, ErrPointRangeInvalid
+	}
+
+	if filter.Aggregate != "" {
+		bucket, ok := pointBuckets[filter.Aggregate]
+		if !ok {
+			return result, ErrPointAggregateInvalid
+		}
+
+		result.Buckets = []PointBucket{}
+		query := p.filter(rel.Select(bucket, "SUM(points.count) AS count"), filter).Group("bucket").SortDesc("bucket")
+		return result, p.repository.FindAll(ctx, &result.Buckets, query)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPointLimit
+	} else if filter.Limit > MaxPointLimit {
+		filter.Limit = MaxPointLimit
+	}
+
+	query := p.filter(rel.Select("points.*"), filter).SortDesc("points.id")
+	if filter.Cursor > 0 {
+		query = query.Where(where.Lt("points.id", filter.Cursor))
+	}
+
+	// fetch one more point to know whether there's a next page.
+	result.Points = []Point{}
+	if err := p.repository.FindAll(ctx, &result.Points, query.Limit(filter.Limit+1)); err != nil {
+		return result, err
+	}
+
+	if len(result.Points) > filter.Limit {
+		result.Points = result.Points[:filter.Limit]
+		result.NextCursor = result.Points[filter.Limit-1].ID
+	}
+
+	return result, p.adjustments(ctx, result.Points)
+}
+
+// adjustments loads the audit record of adjusted points.
+func (p points) adjustments(ctx context.Context, points []Point) error {
+	var (
+		ids         []int
+		adjustments []Adjustment
+	)
+
+	for i := range points {
+		if points[i].SourceType == SourceAdjustment {
+			ids = append(ids, points[i].SourceID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := p.repository.FindAll(ctx, &adjustments, where.InInt("id", ids)); err != nil {
+		return err
+	}
+
+	for i := range points {
+		for j := range adjustments {
+			if points[i].SourceType == SourceAdjustment && points[i].SourceID == adjustments[j].ID {
+				points[i].Adjustment = &adjustments[j]
+			}
+		}
+	}
+
+	return nil
+}
+
+// filter points owned by the user.
+func (p points) filter(query rel.Query, filter PointFilter) rel.Query {
+	query = query.From("points").
+		JoinOn("scores", "scores.id", "points.score_id").
+		Where(where.Eq("scores.user_id", filter.UserID))
+
+	if filter.Name != "" {
+		query = query.Where(where.Eq("points.name", filter.Name))
+	}
+
+	if filter.SourceType != "" {
+		query = query.Where(where.Eq("points.source_type", filter.SourceType), where.Eq("points.source_id", filter.SourceID))
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where(where.Gte("points.created_at", filter.From))
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where(where.Lt("points.created_at", filter.To))
+	}
+
+	return query
+}
@@ -0,0 +1,185 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoints(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		query      = rel.Select("points.*").From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1)).
+				SortDesc("points.id").
+				Limit(51)
+		points = []Point{{ID: 2, Name: "todo completed", Count: 1, ScoreID: 1}, {ID: 1, Name: "todo completed", Count: 1, ScoreID: 1}}
+	)
+
+	repository.ExpectFindAll(query).Result(points)
+
+	result, err := service.Points(ctx, PointFilter{UserID: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, PointPage{Points: points}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestPoints_adjustment(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		query      = rel.Select("points.*").From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1)).
+				SortDesc("points.id").
+				Limit(51)
+		adjustment = Adjustment{ID: 3, UserID: 1, Count: -2, Reason: "duplicate todo", AdjustedBy: 9}
+	)
+
+	repository.ExpectFindAll(query).Result([]Point{
+		{ID: 2, Name: PointAdjusted, Count: -2, ScoreID: 1, SourceType: SourceAdjustment, SourceID: 3},
+		{ID: 1, Name: "todo completed", Count: 1, ScoreID: 1, SourceType: "todo", SourceID: 3},
+	})
+	repository.ExpectFindAll(where.InInt("id", []int{3})).Result([]Adjustment{adjustment})
+
+	result, err := service.Points(ctx, PointFilter{UserID: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, PointPage{Points: []Point{
+		{ID: 2, Name: PointAdjusted, Count: -2, ScoreID: 1, SourceType: SourceAdjustment, SourceID: 3, Adjustment: &adjustment},
+		{ID: 1, Name: "todo completed", Count: 1, ScoreID: 1, SourceType: "todo", SourceID: 3},
+	}}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestPoints_nextPage(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		from       = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		to         = time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+		query      = rel.Select("points.*").From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1)).
+				Where(where.Eq("points.name", "todo completed")).
+				Where(where.Gte("points.created_at", from)).
+				Where(where.Lt("points.created_at", to)).
+				SortDesc("points.id").
+				Where(where.Lt("points.id", 10)).
+				Limit(3)
+	)
+
+	repository.ExpectFindAll(query).Result([]Point{{ID: 9}, {ID: 7}, {ID: 4}})
+
+	result, err := service.Points(ctx, PointFilter{UserID: 1, Name: "todo completed", From: from, To: to, Cursor: 10, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, PointPage{Points: []Point{{ID: 9}, {ID: 7}}, NextCursor: 7}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestPoints_aggregate(t *testing.T) {
+	tests := []struct {
+		aggregate string
+		bucket    string
+	}{
+		{
+			aggregate: AggregateDay,
+			bucket:    "^DATE(points.created_at) AS bucket",
+		},
+		{
+			aggregate: AggregateWeek,
+			bucket:    "^DATE(DATE_SUB(points.created_at, INTERVAL WEEKDAY(points.created_at) DAY)) AS bucket",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.aggregate, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				service    = New(repository, DefaultConfig, events.Nop{})
+				query      = rel.Select(test.bucket, "SUM(points.count) AS count").From("points").
+						JoinOn("scores", "scores.id", "points.score_id").
+						Where(where.Eq("scores.user_id", 1)).
+						Group("bucket").
+						SortDesc("bucket")
+				buckets = []PointBucket{{Bucket: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Count: 4}}
+			)
+
+			repository.ExpectFindAll(query).Result(buckets)
+
+			result, err := service.Points(ctx, PointFilter{UserID: 1, Aggregate: test.aggregate, Limit: 1})
+			assert.Nil(t, err)
+			assert.Equal(t, PointPage{Buckets: buckets}, result)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPoints_invalid(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		from       = time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	)
+
+	_, err := service.Points(ctx, PointFilter{UserID: 1, Aggregate: "year"})
+	assert.Equal(t, ErrPointAggregateInvalid, err)
+
+	_, err = service.Points(ctx, PointFilter{UserID: 1, From: from, To: from})
+	assert.Equal(t, ErrPointRangeInvalid, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestPoints_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		query      = rel.Select("points.*").From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1)).
+				SortDesc("points.id").
+				Limit(101)
+	)
+
+	repository.ExpectFindAll(query).ConnectionClosed()
+
+	_, err := service.Points(ctx, PointFilter{UserID: 1, Limit: 1000})
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	repository.AssertExpectations(t)
+}
+
+func TestPoints_source(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+		query      = rel.Select("points.*").From("points").
+				JoinOn("scores", "scores.id", "points.score_id").
+				Where(where.Eq("scores.user_id", 1)).
+				Where(where.Eq("points.source_type", "todo"), where.Eq("points.source_id", 2)).
+				SortDesc("points.id").
+				Limit(51)
+		points = []Point{{ID: 1, Name: "todo completed", Count: 1, ScoreID: 1, SourceType: "todo", SourceID: 2}}
+	)
+
+	repository.ExpectFindAll(query).Result(points)
+
+	result, err := service.Points(ctx, PointFilter{UserID: 1, SourceType: "todo", SourceID: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, PointPage{Points: points}, result)
+	repository.AssertExpectations(t)
+}
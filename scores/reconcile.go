@@ -0,0 +1,82 @@
+package scores
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+// Discrepancy between score's total point and the sum of its points.
+type Discrepancy struct {
+	ScoreID    int `json:"score_id"`
+	UserID     int `json:"user_id"`
+	TotalPoint int `json:"total_point"`
+	// Expected total point, summed from points.
+	Expected int `json:"expected"`
+}
+
+// Drift between expected and actual total point.
+func (d Discrepancy) Drift() int {
+	return d.TotalPoint - d.Expected
+}
+
+type reconcile struct {
+	repository rel.Repository
+}
+
+// Reconcile recomputes total point of every score from its points and returns the discrepancies.
+// When fix is true, discrepancies are corrected within a transaction.
+func (r reconcile) Reconcile(ctx context.Context, fix bool) ([]Discrepancy, error) {
+	var (
+		result []Discrepancy
+		query  = rel.Select("scores.id AS score_id", "scores.user_id", "scores.total_point", "^COALESCE(SUM(points.count), 0) AS expected").
+			From("scores").
+			JoinWith("LEFT JOIN", "points", "points.score_id", "scores.id").
+			Group("scores.id", "scores.user_id", "scores.total_point").
+			Havingf("scores.total_point <> COALESCE(SUM(points.count), 0)").
+			SortAsc("scores.id")
+	)
+
+	if err := r.repository.FindAll(ctx, &result, query); err != nil {
+		return nil, err
+	}
+
+	if !fix || len(result) == 0 {
+		return result, nil
+	}
+
+	return result, r.repository.Transaction(ctx, func(ctx context.Context) error {
+		for i := range result {
+			if err := r.fix(ctx, &result[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// fix total point of a score, points are summed again after the score is locked since it may be earning points concurrently.
+func (r reconcile) fix(ctx context.Context, discrepancy *Discrepancy) error {
+	var (
+		score Score
+		err   error
+	)
+
+	if err := r.repository.Find(ctx, &score, where.Eq("id", discrepancy.ScoreID), rel.ForUpdate()); err != nil {
+		return err
+	}
+
+	if discrepancy.Expected, err = r.repository.Aggregate(ctx, rel.From("points").Where(where.Eq("score_id", score.ID)), "sum", "count"); err != nil {
+		return err
+	}
+
+	discrepancy.TotalPoint = score.TotalPoint
+	if score.TotalPoint == discrepancy.Expected {
+		return nil
+	}
+
+	score.TotalPoint = discrepancy.Expected
+	return r.repository.Update(ctx, &score)
+}
@@ -0,0 +1,97 @@
+package scores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+var reconcileQuery = rel.Select("scores.id AS score_id", "scores.user_id", "scores.total_point", "^COALESCE(SUM(points.count), 0) AS expected").
+	From("scores").
+	JoinWith("LEFT JOIN", "points", "points.score_id", "scores.id").
+	Group("scores.id", "scores.user_id", "scores.total_point").
+	Havingf("scores.total_point <> COALESCE(SUM(points.count), 0)").
+	SortAsc("scores.id")
+
+func TestReconcile(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		repository    = reltest.New()
+		service       = New(repository, DefaultConfig, events.Nop{})
+		discrepancies = []Discrepancy{{ScoreID: 1, UserID: 2, TotalPoint: 10, Expected: 12}}
+	)
+
+	repository.ExpectFindAll(reconcileQuery).Result(discrepancies)
+
+	result, err := service.Reconcile(ctx, false)
+	assert.Nil(t, err)
+	assert.Equal(t, discrepancies, result)
+	assert.Equal(t, -2, result[0].Drift())
+	repository.AssertExpectations(t)
+}
+
+func TestReconcile_fix(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	repository.ExpectFindAll(reconcileQuery).Result([]Discrepancy{
+		{ScoreID: 1, UserID: 2, TotalPoint: 10, Expected: 12},
+		{ScoreID: 3, UserID: 4, TotalPoint: 5, Expected: 4},
+	})
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(where.Eq("id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 2, TotalPoint: 10})
+		repository.ExpectAggregate(rel.From("points").Where(where.Eq("score_id", 1)), "sum", "count").Result(12)
+		repository.ExpectUpdate().For(&Score{ID: 1, UserID: 2, TotalPoint: 12})
+		// score 3 has been fixed by concurrent earn.
+		repository.ExpectFind(where.Eq("id", 3), rel.ForUpdate()).Result(Score{ID: 3, UserID: 4, TotalPoint: 6})
+		repository.ExpectAggregate(rel.From("points").Where(where.Eq("score_id", 3)), "sum", "count").Result(6)
+	})
+
+	result, err := service.Reconcile(ctx, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []Discrepancy{
+		{ScoreID: 1, UserID: 2, TotalPoint: 10, Expected: 12},
+		{ScoreID: 3, UserID: 4, TotalPoint: 6, Expected: 6},
+	}, result)
+	repository.AssertExpectations(t)
+}
+
+func TestReconcile_fixError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	repository.ExpectFindAll(reconcileQuery).Result([]Discrepancy{{ScoreID: 1, UserID: 2, TotalPoint: 10, Expected: 12}})
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(where.Eq("id", 1), rel.ForUpdate()).Result(Score{ID: 1, UserID: 2, TotalPoint: 10})
+		repository.ExpectAggregate(rel.From("points").Where(where.Eq("score_id", 1)), "sum", "count").ConnectionClosed()
+	})
+
+	_, err := service.Reconcile(ctx, true)
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	repository.AssertExpectations(t)
+}
+
+func TestReconcile_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, DefaultConfig, events.Nop{})
+	)
+
+	repository.ExpectFindAll(reconcileQuery).ConnectionClosed()
+
+	_, err := service.Reconcile(ctx, true)
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+	repository.AssertExpectations(t)
+}
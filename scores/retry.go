@@ -0,0 +1,64 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+)
+
+const (
+	mysqlErrLockWaitTimeout = 1205
+	mysqlErrLockDeadlock    = 1213
+)
+
+var (
+	retryAttempts = 5
+	retryBackoff  = 10 * time.Millisecond
+)
+
+type retryKey struct{}
+
+// Retry runs fn, usually a transaction, again with exponential backoff when it fails because of deadlock or lock wait timeout.
+// Retry nested within another retry runs fn once, because a deadlock rolls back the whole transaction and only the outermost retry can start it over.
+func Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(retryKey{}) != nil {
+		return fn(ctx)
+	}
+
+	ctx = context.WithValue(ctx, retryKey{}, true)
+
+	var err error
+	for attempt := 0; attempt < retryAttempts; attempt++ {
+		if attempt > 0 {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// full jitter, so concurrent writers don't collide again at the same time.
+			backoff := time.Duration(rand.Int63n(int64(retryBackoff<<attempt) + 1))
+			logger.Warn("retrying transaction", zap.Error(err), zap.Int("attempt", attempt), zap.Duration("backoff", backoff))
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		if err = fn(ctx); !retryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func retryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) &&
+		(mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout)
+}
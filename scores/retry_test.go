@@ -0,0 +1,107 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	var (
+		deadlock    = &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+		lockTimeout = &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+		duplicate   = &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	)
+
+	tests := []struct {
+		name     string
+		errs     []error
+		err      error
+		attempts int
+	}{
+		{
+			name:     "ok",
+			errs:     []error{nil},
+			attempts: 1,
+		},
+		{
+			name:     "deadlock",
+			errs:     []error{deadlock, lockTimeout, nil},
+			attempts: 3,
+		},
+		{
+			name:     "not retryable",
+			errs:     []error{duplicate},
+			err:      duplicate,
+			attempts: 1,
+		},
+		{
+			name:     "exhausted",
+			errs:     []error{deadlock, deadlock, deadlock, deadlock, deadlock},
+			err:      deadlock,
+			attempts: 5,
+		},
+	}
+
+	defer func(backoff time.Duration) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = time.Microsecond
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			err := Retry(context.TODO(), func(ctx context.Context) error {
+				attempts++
+				return test.errs[attempts-1]
+			})
+
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.attempts, attempts)
+		})
+	}
+}
+
+func TestRetry_nested(t *testing.T) {
+	var (
+		deadlock = &mysql.MySQLError{Number: 1213}
+		inner    = 0
+		outer    = 0
+	)
+
+	defer func(backoff time.Duration) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = time.Microsecond
+
+	err := Retry(context.TODO(), func(ctx context.Context) error {
+		outer++
+		return Retry(ctx, func(ctx context.Context) error {
+			inner++
+			if inner < 2 {
+				return deadlock
+			}
+			return nil
+		})
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, outer)
+	assert.Equal(t, 2, inner)
+}
+
+func TestRetry_canceled(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.TODO())
+		attempts    = 0
+	)
+
+	err := Retry(ctx, func(ctx context.Context) error {
+		attempts++
+		cancel()
+		return errors.Join(errors.New("update scores"), &mysql.MySQLError{Number: 1213})
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,138 @@
+package scores
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrRuleNameBlank validation error.
+	ErrRuleNameBlank = errors.New("Rule name can't be blank")
+	// ErrRuleEventBlank validation error.
+	ErrRuleEventBlank = errors.New("Rule event can't be blank")
+	// DefaultRules used when no config file is configured.
+	DefaultRules = Rules{
+		{Name: "todo completed", Event: "todo.completed", Points: 1, Once: true},
+		{Name: "todo uncompleted", Event: "todo.uncompleted", Points: -2, Once: true},
+	}
+)
+
+// Event emitted by other domain to be evaluated against rules.
+type Event struct {
+	Name     string
+	UserID   int
+	Priority int
+	Overdue  bool
+	Tags     []string
+	// Source that emitted the event, such as the todo.
+	Source Source
+}
+
+// Conditions that must be satisfied by an event, empty condition always match.
+type Conditions struct {
+	Priority    *int   `json:"priority,omitempty"`
+	MinPriority *int   `json:"min_priority,omitempty"`
+	Overdue     *bool  `json:"overdue,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+}
+
+// Match returns true if event satisfies all conditions.
+func (c Conditions) Match(event Event) bool {
+	switch {
+	case c.Priority != nil && event.Priority != *c.Priority:
+		return false
+	case c.MinPriority != nil && event.Priority < *c.MinPriority:
+		return false
+	case c.Overdue != nil && event.Overdue != *c.Overdue:
+		return false
+	case c.Tag != "" && !hasTag(event.Tags, c.Tag):
+		return false
+	}
+
+	return true
+}
+
+// Multiplier scales points of a rule when the conditions are matched.
+type Multiplier struct {
+	Conditions
+	Factor float64 `json:"factor"`
+}
+
+// Rule defines how many points earned for an event.
+type Rule struct {
+	// Name is recorded as point name.
+	Name        string       `json:"name"`
+	Event       string       `json:"event"`
+	Conditions  Conditions   `json:"conditions"`
+	Points      int          `json:"points"`
+	Multipliers []Multiplier `json:"multipliers"`
+	// DailyCap limits total points earned from this rule per user per day, days start at midnight UTC. Zero means no limit.
+	DailyCap int `json:"daily_cap"`
+	// Once awards points from this rule at most once per event source.
+	Once bool `json:"once"`
+}
+
+// Match returns true if the rule applies to event.
+func (r Rule) Match(event Event) bool {
+	return r.Event == event.Name && r.Conditions.Match(event)
+}
+
+// Calculate points for event after multipliers applied.
+func (r Rule) Calculate(event Event) int {
+	points := float64(r.Points)
+	for _, multiplier := range r.Multipliers {
+		if multiplier.Match(event) {
+			points *= multiplier.Factor
+		}
+	}
+
+	return int(math.Round(points))
+}
+
+// Source of points earned from event, with dedupe key when the rule only awards once.
+func (r Rule) Source(event Event) Source {
+	source := event.Source
+	if r.Once && source.Type != "" {
+		source.DedupeKey = fmt.Sprintf("%s:%s:%d", r.Name, source.Type, source.ID)
+	}
+
+	return source
+}
+
+// Validate rule.
+func (r Rule) Validate() error {
+	var err error
+	switch {
+	case r.Name == "":
+		err = ErrRuleNameBlank
+	case r.Event == "":
+		err = ErrRuleEventBlank
+	}
+
+	return err
+}
+
+// Rules is a set of rule.
+type Rules []Rule
+
+// Validate all rules.
+func (rs Rules) Validate() error {
+	for i := range rs {
+		if err := rs[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasTag(tags []string, tag string) bool {
+	for i := range tags {
+		if tags[i] == tag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package scores
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditions_Match(t *testing.T) {
+	var (
+		high    = 3
+		overdue = true
+	)
+
+	tests := []struct {
+		name       string
+		conditions Conditions
+		event      Event
+		match      bool
+	}{
+		{
+			name:  "empty",
+			event: Event{Name: "todo.completed"},
+			match: true,
+		},
+		{
+			name:       "priority match",
+			conditions: Conditions{Priority: &high},
+			event:      Event{Priority: 3},
+			match:      true,
+		},
+		{
+			name:       "priority not match",
+			conditions: Conditions{Priority: &high},
+			event:      Event{Priority: 2},
+		},
+		{
+			name:       "min priority not match",
+			conditions: Conditions{MinPriority: &high},
+			event:      Event{Priority: 1},
+		},
+		{
+			name:       "overdue match",
+			conditions: Conditions{Overdue: &overdue},
+			event:      Event{Overdue: true},
+			match:      true,
+		},
+		{
+			name:       "overdue not match",
+			conditions: Conditions{Overdue: &overdue},
+			event:      Event{},
+		},
+		{
+			name:       "tag match",
+			conditions: Conditions{Tag: "work"},
+			event:      Event{Tags: []string{"home", "work"}},
+			match:      true,
+		},
+		{
+			name:       "tag not match",
+			conditions: Conditions{Tag: "work"},
+			event:      Event{Tags: []string{"home"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.match, test.conditions.Match(test.event))
+		})
+	}
+}
+
+func TestRule_Calculate(t *testing.T) {
+	var (
+		high    = 3
+		overdue = true
+		rule    = Rule{
+			Name:   "todo completed",
+			Event:  "todo.completed",
+			Points: 2,
+			Multipliers: []Multiplier{
+				{Conditions: Conditions{Priority: &high}, Factor: 2},
+				{Conditions: Conditions{Overdue: &overdue}, Factor: 0.5},
+			},
+		}
+	)
+
+	assert.True(t, rule.Match(Event{Name: "todo.completed"}))
+	assert.False(t, rule.Match(Event{Name: "todo.uncompleted"}))
+	assert.Equal(t, 2, rule.Calculate(Event{}))
+	assert.Equal(t, 4, rule.Calculate(Event{Priority: 3}))
+	assert.Equal(t, 1, rule.Calculate(Event{Overdue: true}))
+	assert.Equal(t, 2, rule.Calculate(Event{Priority: 3, Overdue: true}))
+}
+
+func TestRule_Source(t *testing.T) {
+	var (
+		source = Source{Type: "todo", ID: 2}
+		rule   = Rule{Name: "todo completed", Event: "todo.completed", Points: 1}
+		once   = Rule{Name: "todo completed", Event: "todo.completed", Points: 1, Once: true}
+	)
+
+	assert.Equal(t, source, rule.Source(Event{Source: source}))
+	assert.Equal(t, Source{Type: "todo", ID: 2, DedupeKey: "todo completed:todo:2"}, once.Source(Event{Source: source}))
+	assert.Equal(t, Source{}, once.Source(Event{}))
+}
+
+func TestRule_Validate(t *testing.T) {
+	assert.Equal(t, ErrRuleNameBlank, Rule{Event: "todo.completed"}.Validate())
+	assert.Equal(t, ErrRuleEventBlank, Rule{Name: "todo completed"}.Validate())
+	assert.Nil(t, Rule{Name: "todo completed", Event: "todo.completed"}.Validate())
+}
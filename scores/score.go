@@ -6,8 +6,17 @@ import (
 
 // Score stores total points.
 type Score struct {
-	ID         int       `json:"id"`
-	TotalPoint int       `json:"total_point"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID            int `json:"id"`
+	UserID        int `json:"user_id"`
+	TotalPoint    int `json:"total_point"`
+	CurrentStreak int `json:"current_streak"`
+	LongestStreak int `json:"longest_streak"`
+	// StreakOn is the last day (yyyy-mm-dd) with completion.
+	StreakOn      string `json:"streak_on"`
+	Level         int    `json:"level" db:"-"`
+	LevelProgress int    `json:"level_progress" db:"-"`
+	// NextLevelAt is total point required for the next level, zero when the highest level is reached.
+	NextLevelAt int       `json:"next_level_at" db:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
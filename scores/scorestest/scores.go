@@ -0,0 +1,82 @@
+package scorestest
+
+import (
+	context "context"
+
+	scores "github.com/go-rel/gin-example/scores"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock score functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockFind util.
+func MockFind(result scores.Score, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Find", mock.Anything, mock.Anything, userID).
+			Return(func(ctx context.Context, out *scores.Score, userID int) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockLeaderboard util.
+func MockLeaderboard(result scores.Leaderboard, userID int, window string, limit int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Leaderboard", mock.Anything, userID, window, limit).
+			Return(result, err)
+	}
+}
+
+// MockPoints util.
+func MockPoints(result scores.PointPage, filter scores.PointFilter, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Points", mock.Anything, filter).
+			Return(result, err)
+	}
+}
+
+// MockReconcile util.
+func MockReconcile(result []scores.Discrepancy, fix bool, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Reconcile", mock.Anything, fix).
+			Return(result, err)
+	}
+}
+
+// MockEarn util.
+func MockEarn(userID int, name string, count int, source scores.Source, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Earn", mock.Anything, userID, name, count, source).
+			Return(err)
+	}
+}
+
+// MockSpend util.
+func MockSpend(userID int, name string, count int, source scores.Source, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Spend", mock.Anything, userID, name, count, source).
+			Return(err)
+	}
+}
+
+// MockAdjust util.
+func MockAdjust(result scores.Adjustment, adjustment scores.Adjustment, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Adjust", mock.Anything, &adjustment).
+			Return(func(ctx context.Context, out *scores.Adjustment) error {
+				*out = result
+				return err
+			})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	scores "github.com/go-rel/gin-example/scores"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -13,16 +15,137 @@ type Service struct {
 	mock.Mock
 }
 
-// Earn provides a mock function with given fields: ctx, name, count
-func (_m *Service) Earn(ctx context.Context, name string, count int) error {
-	ret := _m.Called(ctx, name, count)
+// Find provides a mock function with given fields: ctx, score, userID
+func (_m *Service) Find(ctx context.Context, score *scores.Score, userID int) error {
+	ret := _m.Called(ctx, score, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scores.Score, int) error); ok {
+		r0 = rf(ctx, score, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Earn provides a mock function with given fields: ctx, userID, name, count, source
+func (_m *Service) Earn(ctx context.Context, userID int, name string, count int, source scores.Source) error {
+	ret := _m.Called(ctx, userID, name, count, source)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, scores.Source) error); ok {
+		r0 = rf(ctx, userID, name, count, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Spend provides a mock function with given fields: ctx, userID, name, count, source
+func (_m *Service) Spend(ctx context.Context, userID int, name string, count int, source scores.Source) error {
+	ret := _m.Called(ctx, userID, name, count, source)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int, scores.Source) error); ok {
+		r0 = rf(ctx, userID, name, count, source)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Adjust provides a mock function with given fields: ctx, adjustment
+func (_m *Service) Adjust(ctx context.Context, adjustment *scores.Adjustment) error {
+	ret := _m.Called(ctx, adjustment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scores.Adjustment) error); ok {
+		r0 = rf(ctx, adjustment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Evaluate provides a mock function with given fields: ctx, event
+func (_m *Service) Evaluate(ctx context.Context, event scores.Event) error {
+	ret := _m.Called(ctx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		r0 = rf(ctx, name, count)
+	if rf, ok := ret.Get(0).(func(context.Context, scores.Event) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
 
 	return r0
 }
+
+// Leaderboard provides a mock function with given fields: ctx, userID, window, limit
+func (_m *Service) Leaderboard(ctx context.Context, userID int, window string, limit int) (scores.Leaderboard, error) {
+	ret := _m.Called(ctx, userID, window, limit)
+
+	var r0 scores.Leaderboard
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, int) scores.Leaderboard); ok {
+		r0 = rf(ctx, userID, window, limit)
+	} else {
+		r0 = ret.Get(0).(scores.Leaderboard)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, int) error); ok {
+		r1 = rf(ctx, userID, window, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Points provides a mock function with given fields: ctx, filter
+func (_m *Service) Points(ctx context.Context, filter scores.PointFilter) (scores.PointPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 scores.PointPage
+	if rf, ok := ret.Get(0).(func(context.Context, scores.PointFilter) scores.PointPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(scores.PointPage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, scores.PointFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reconcile provides a mock function with given fields: ctx, fix
+func (_m *Service) Reconcile(ctx context.Context, fix bool) ([]scores.Discrepancy, error) {
+	ret := _m.Called(ctx, fix)
+
+	var r0 []scores.Discrepancy
+	if rf, ok := ret.Get(0).(func(context.Context, bool) []scores.Discrepancy); ok {
+		r0 = rf(ctx, fix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scores.Discrepancy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, fix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -2,29 +2,56 @@ package scores
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
 )
 
 //go:generate mockery --name=Service --case=underscore --output scorestest --outpkg scorestest
 
-// Service instance for todo's domain.
+// Service instance for score's domain.
 // Any operation done to any of object within this domain should use this service.
 type Service interface {
-	Earn(ctx context.Context, name string, count int) error
+	Find(ctx context.Context, score *Score, userID int) error
+	Earn(ctx context.Context, userID int, name string, count int, source Source) error
+	Spend(ctx context.Context, userID int, name string, count int, source Source) error
+	Adjust(ctx context.Context, adjustment *Adjustment) error
+	Evaluate(ctx context.Context, event Event) error
+	Leaderboard(ctx context.Context, userID int, window string, limit int) (Leaderboard, error)
+	Points(ctx context.Context, filter PointFilter) (PointPage, error)
+	Reconcile(ctx context.Context, fix bool) ([]Discrepancy, error)
 }
 
 // beside embeding the struct, you can also declare the function directly on this struct.
 // the advantage of embedding the struct is it allows spreading the implementation across multiple files.
 type service struct {
+	find
 	earn
+	spend
+	adjust
+	evaluate
+	leaderboard
+	points
+	reconcile
 }
 
 var _ Service = (*service)(nil)
 
-// New Scores service.
-func New(repository rel.Repository) Service {
+// New Scores service, publisher is notified every time a user earns points, levels up or the score changes,
+// and listeners are notified every time points are earned.
+func New(repository rel.Repository, config Config, publisher events.Publisher, listeners ...Listener) Service {
+	earn := earn{repository: repository, streak: config.Streak, levels: config.Levels, publisher: publisher, listeners: listeners, now: time.Now}
+	spend := spend{repository: repository, levels: config.Levels, publisher: publisher, now: time.Now}
+
 	return service{
-		earn: earn{repository: repository},
+		find:        find{repository: repository, streak: config.Streak, levels: config.Levels, now: time.Now},
+		earn:        earn,
+		spend:       spend,
+		adjust:      adjust{repository: repository, earn: earn, spend: spend},
+		evaluate:    evaluate{earn: earn, rules: config.Rules},
+		leaderboard: leaderboard{repository: repository, now: time.Now},
+		points:      points{repository: repository},
+		reconcile:   reconcile{repository: repository},
 	}
 }
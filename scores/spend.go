@@ -0,0 +1,65 @@
+package scores
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+var (
+	// ErrPointInsufficient error.
+	ErrPointInsufficient = errors.New("Insufficient points")
+	// ErrPointSpendInvalid error.
+	ErrPointSpendInvalid = errors.New("Points to spend must be greater than zero")
+)
+
+type spend struct {
+	repository rel.Repository
+	levels     Levels
+	publisher  events.Publisher
+	now        func() time.Time
+}
+
+// Spend points by recording a negative point, fails when total point is lower than count.
+// Balance is checked and deducted by a single conditional update, so concurrent spend can't overdraw the score.
+func (s spend) Spend(ctx context.Context, userID int, name string, count int, source Source) error {
+	if count <= 0 {
+		return ErrPointSpendInvalid
+	}
+
+	return events.Commit(ctx, func(ctx context.Context) error {
+		return s.repository.Transaction(ctx, func(ctx context.Context) error {
+			var (
+				score Score
+				query = rel.From("scores").Where(where.Eq("user_id", userID), where.Gte("total_point", count))
+			)
+
+			updated, err := s.repository.UpdateAny(ctx, query, rel.DecBy("total_point", count), rel.Set("updated_at", s.now()))
+			if err != nil {
+				return err
+			}
+
+			if updated == 0 {
+				return ErrPointInsufficient
+			}
+
+			if err := s.repository.Find(ctx, &score, where.Eq("user_id", userID)); err != nil {
+				return err
+			}
+
+			point := source.point(name, -count)
+			point.ScoreID = score.ID
+			if err := s.repository.Insert(ctx, &point); err != nil {
+				return err
+			}
+
+			s.levels.apply(&score)
+			events.AfterCommit(ctx, s.publisher, events.Event{Name: EventChanged, UserID: userID, Data: score})
+			return nil
+		})
+	})
+}
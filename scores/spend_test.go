@@ -0,0 +1,112 @@
+package scores
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSpendService(repository *reltest.Repository) service {
+	service := New(repository, DefaultConfig, events.Nop{}).(service)
+	service.spend.now = func() time.Time { return earnNow }
+	return service
+}
+
+func expectDeduct(repository *reltest.Repository, userID int, count int) *reltest.MockUpdateAny {
+	return repository.ExpectUpdateAny(
+		rel.From("scores").Where(where.Eq("user_id", userID), where.Gte("total_point", count)),
+		rel.DecBy("total_point", count), rel.Set("updated_at", earnNow),
+	)
+}
+
+func TestSpend(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newSpendService(repository)
+		source     = Source{Type: "redemption", ID: 3}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectDeduct(repository, 1, 10).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 2, UserID: 1, TotalPoint: 5})
+		repository.ExpectInsert().For(&Point{Name: "reward redeemed", Count: -10, ScoreID: 2, SourceType: "redemption", SourceID: 3})
+	})
+
+	assert.Nil(t, service.Spend(ctx, 1, "reward redeemed", 10, source))
+	repository.AssertExpectations(t)
+}
+
+func TestSpend_published(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newSpendService(repository)
+		bus        = events.NewBus()
+		published  []events.Event
+	)
+
+	service.spend.publisher = bus
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		event.At = earnNow
+		published = append(published, event)
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectDeduct(repository, 1, 10).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("user_id", 1)).Result(Score{ID: 2, UserID: 1, TotalPoint: 5})
+		repository.ExpectInsert().For(&Point{Name: "reward redeemed", Count: -10, ScoreID: 2})
+	})
+
+	assert.Nil(t, service.Spend(ctx, 1, "reward redeemed", 10, Source{}))
+	assert.Equal(t, []events.Event{{Name: EventChanged, UserID: 1, Data: Score{ID: 2, UserID: 1, TotalPoint: 5, Level: 1, LevelProgress: 5, NextLevelAt: 10}, At: earnNow}}, published)
+	repository.AssertExpectations(t)
+}
+
+func TestSpend_insufficient(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newSpendService(repository)
+	)
+
+	// balance was spent by a concurrent redemption, or user doesn't have a score yet.
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectDeduct(repository, 1, 10).UpdatedCount(0)
+	})
+
+	assert.Equal(t, ErrPointInsufficient, service.Spend(ctx, 1, "reward redeemed", 10, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestSpend_invalid(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newSpendService(repository)
+	)
+
+	assert.Equal(t, ErrPointSpendInvalid, service.Spend(ctx, 1, "reward redeemed", 0, Source{}))
+	repository.AssertExpectations(t)
+}
+
+func TestSpend_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newSpendService(repository)
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		expectDeduct(repository, 1, 10).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Spend(ctx, 1, "reward redeemed", 10, Source{}))
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package scores
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	streakDateLayout = "2006-01-02"
+)
+
+var (
+	// DefaultStreak used when no config file is configured.
+	DefaultStreak = Streak{
+		TimeZone: "Local",
+		Names:    []string{"todo completed"},
+		Milestones: []Milestone{
+			{Days: 7, Points: 5},
+			{Days: 30, Points: 20},
+		},
+	}
+)
+
+// Milestone awards bonus points when streak reaches the given days.
+type Milestone struct {
+	Days   int `json:"days"`
+	Points int `json:"points"`
+}
+
+// Name of the bonus point.
+func (m Milestone) Name() string {
+	return fmt.Sprintf("streak %d days", m.Days)
+}
+
+// Streak configuration, a streak is consecutive days with at least one completion.
+type Streak struct {
+	// TimeZone used to determine the day of completion.
+	TimeZone string `json:"time_zone"`
+	// Names of points that count as completion.
+	Names      []string    `json:"names"`
+	Milestones []Milestone `json:"milestones"`
+}
+
+// Validate streak.
+func (s Streak) Validate() error {
+	_, err := time.LoadLocation(s.TimeZone)
+	return err
+}
+
+// counts returns true if earning the point counts as completion.
+func (s Streak) counts(name string, count int) bool {
+	if count <= 0 {
+		return false
+	}
+
+	for i := range s.Names {
+		if s.Names[i] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// day formats date of t in streak's time zone.
+func (s Streak) day(t time.Time, offset int) string {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, location).Format(streakDateLayout)
+}
+
+// milestone reached when streak is at the given days.
+func (s Streak) milestone(days int) (Milestone, bool) {
+	for _, milestone := range s.Milestones {
+		if milestone.Days == days {
+			return milestone, true
+		}
+	}
+
+	return Milestone{}, false
+}
+
+// refresh resets current streak when it's already broken at now.
+func (s Streak) refresh(score *Score, now time.Time) {
+	if score.StreakOn != s.day(now, 0) && score.StreakOn != s.day(now, -1) {
+		score.CurrentStreak = 0
+	}
+}
@@ -0,0 +1,42 @@
+package scores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreak_day(t *testing.T) {
+	var (
+		now     = time.Date(2026, 12, 31, 20, 0, 0, 0, time.UTC)
+		utc     = Streak{TimeZone: "UTC"}
+		jakarta = Streak{TimeZone: "Asia/Jakarta"}
+	)
+
+	assert.Equal(t, "2026-12-31", utc.day(now, 0))
+	assert.Equal(t, "2026-12-30", utc.day(now, -1))
+	assert.Equal(t, "2027-01-01", jakarta.day(now, 0))
+	assert.Equal(t, "2026-12-31", jakarta.day(now, -1))
+}
+
+func TestStreak_counts(t *testing.T) {
+	streak := Streak{Names: []string{"todo completed"}}
+
+	assert.True(t, streak.counts("todo completed", 1))
+	assert.False(t, streak.counts("todo completed", 0))
+	assert.False(t, streak.counts("todo uncompleted", 1))
+}
+
+func TestStreak_milestone(t *testing.T) {
+	milestone, ok := DefaultStreak.milestone(7)
+	assert.True(t, ok)
+	assert.Equal(t, Milestone{Days: 7, Points: 5}, milestone)
+
+	_, ok = DefaultStreak.milestone(8)
+	assert.False(t, ok)
+}
+
+func TestMilestone_Name(t *testing.T) {
+	assert.Equal(t, "streak 7 days", Milestone{Days: 7, Points: 5}.Name())
+}
@@ -0,0 +1,94 @@
+// Package services wires domain services, shared by every api serving them.
+// Events published by a service are handled by the same subscribers regardless of the api the request came from.
+package services
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/go-rel/gin-example/achievements"
+	"github.com/go-rel/gin-example/challenges"
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/rewards"
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+	"github.com/go-rel/gin-example/webhooks"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "services")))
+
+	// streamed events are changes that clients may reflect without reloading, published after they're committed.
+	streamed = map[string]bool{
+		todos.EventTodoCreated:  true,
+		todos.EventTodoUpdated:  true,
+		todos.EventTodoDeleted:  true,
+		todos.EventTodosCleared: true,
+		scores.EventChanged:     true,
+	}
+)
+
+// Services of every domain.
+type Services struct {
+	Bus          *events.Bus
+	Stream       *events.Buffer
+	Achievements achievements.Service
+	Scores       scores.Service
+	Todos        todos.Service
+	Rewards      rewards.Service
+	Challenges   challenges.Service
+	Webhooks     webhooks.Service
+}
+
+// New services, scoring config is loaded from SCORE_CONFIG and the number of buffered streamed events from EVENTS_BUFFER_SIZE.
+func New(repository rel.Repository) Services {
+	config, err := scores.LoadConfig(os.Getenv("SCORE_CONFIG"))
+	if err != nil {
+		panic(err)
+	}
+
+	// default buffer size is used when it's empty or invalid.
+	size, _ := strconv.Atoi(os.Getenv("EVENTS_BUFFER_SIZE"))
+
+	var (
+		bus          = events.NewBus()
+		stream       = events.NewBuffer(size)
+		achievements = achievements.New(repository, bus)
+		evaluate     = &listener{}
+		scores       = scores.New(repository, config, bus, achievements, evaluate)
+		challenges   = challenges.New(repository, scores)
+		webhooks     = webhooks.New(repository, webhooks.NewClient())
+	)
+
+	// challenges award their bonus using scores, so they listen to earned points once both are created.
+	evaluate.Listener = challenges
+
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		logger.Info("event published", zap.String("name", event.Name), zap.Int("user_id", event.UserID))
+	})
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		if streamed[event.Name] {
+			stream.Publish(ctx, event)
+		}
+	})
+	bus.Subscribe(webhooks.Enqueue)
+
+	return Services{
+		Bus:          bus,
+		Stream:       stream,
+		Achievements: achievements,
+		Scores:       scores,
+		Todos:        todos.New(repository, scores, bus),
+		Rewards:      rewards.New(repository, scores),
+		Challenges:   challenges,
+		Webhooks:     webhooks,
+	}
+}
+
+// listener of scores that's bound after scores is created.
+type listener struct {
+	scores.Listener
+}
@@ -3,13 +3,16 @@ package todos
 import (
 	"context"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
 )
 
 type clear struct {
 	repository rel.Repository
+	publisher  events.Publisher
 }
 
-func (c clear) Clear(ctx context.Context) {
-	c.repository.MustDeleteAny(ctx, rel.From("todos"))
+func (c clear) Clear(ctx context.Context, userID int) {
+	c.repository.MustDeleteAny(ctx, rel.From("todos").Where(rel.Eq("user_id", userID)))
+	events.AfterCommit(ctx, c.publisher, events.Event{Name: EventTodosCleared, UserID: userID})
 }
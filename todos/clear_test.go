@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -13,14 +14,23 @@ func TestClear(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository, nil)
+		bus        = events.NewBus()
+		service    = New(repository, nil, bus)
+		published  []events.Event
 	)
 
-	repository.ExpectDeleteAny(rel.From("todos")).Unsafe()
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		published = append(published, event)
+	})
+
+	repository.ExpectDeleteAny(rel.From("todos").Where(rel.Eq("user_id", 1)))
 
 	assert.NotPanics(t, func() {
-		service.Clear(ctx)
+		service.Clear(ctx, 1)
 	})
+	assert.Len(t, published, 1)
+	assert.Equal(t, EventTodosCleared, published[0].Name)
+	assert.Equal(t, 1, published[0].UserID)
 
 	repository.AssertExpectations(t)
 }
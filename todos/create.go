@@ -2,7 +2,9 @@ package todos
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/gin-example/scores"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
@@ -11,6 +13,7 @@ import (
 type create struct {
 	repository rel.Repository
 	scores     scores.Service
+	publisher  events.Publisher
 }
 
 func (c create) Create(ctx context.Context, todo *Todo) error {
@@ -19,14 +22,21 @@ func (c create) Create(ctx context.Context, todo *Todo) error {
 		return err
 	}
 
-	// if completed, then earn a point.
+	// if completed, then let scores evaluate the points.
+	// retried as a whole when deadlocked by concurrent earn.
 	if todo.Completed {
-		return c.repository.Transaction(ctx, func(ctx context.Context) error {
-			c.repository.MustInsert(ctx, todo)
-			return c.scores.Earn(ctx, "todo completed", 1)
+		return scores.Retry(ctx, func(ctx context.Context) error {
+			return events.Commit(ctx, func(ctx context.Context) error {
+				return c.repository.Transaction(ctx, func(ctx context.Context) error {
+					c.repository.MustInsert(ctx, todo)
+					events.AfterCommit(ctx, c.publisher, todo.Change(EventTodoCreated))
+					return c.scores.Evaluate(ctx, todo.Event(EventTodoCompleted, time.Now()))
+				})
+			})
 		})
 	}
 
 	c.repository.MustInsert(ctx, todo)
+	events.AfterCommit(ctx, c.publisher, todo.Change(EventTodoCreated))
 	return nil
 }
@@ -2,8 +2,11 @@ package todos
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/gin-example/scores/scorestest"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -15,8 +18,8 @@ func TestCreate(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{Title: "Sleep"}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{UserID: 1, Title: "Sleep"}
 	)
 
 	repository.ExpectInsert().For(&todo)
@@ -33,12 +36,15 @@ func TestCreate_completed(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{Title: "Sleep", Completed: true}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{UserID: 1, Title: "Sleep", Completed: true}
 	)
 
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo completed", 1).Return(nil)
+		// points are linked to the inserted todo.
+		inserted := todo
+		inserted.ID = 1
+		scores.On("Evaluate", mock.Anything, inserted.Event(EventTodoCompleted, time.Now())).Return(nil)
 		repository.ExpectInsert().For(&todo)
 	})
 
@@ -49,12 +55,58 @@ func TestCreate_completed(t *testing.T) {
 	scores.AssertExpectations(t)
 }
 
+func TestCreate_publishedAfterCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		published []string
+	}{
+		{
+			name:      "committed",
+			published: []string{EventTodoCreated},
+		},
+		{
+			name: "rolled back",
+			err:  errors.New("deadlock"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				scores     = &scorestest.Service{}
+				bus        = events.NewBus()
+				service    = New(repository, scores, bus)
+				todo       = Todo{UserID: 1, Title: "Sleep", Completed: true}
+				published  []string
+			)
+
+			bus.Subscribe(func(ctx context.Context, event events.Event) {
+				published = append(published, event.Name)
+			})
+
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				repository.ExpectInsert().For(&todo)
+				scores.On("Evaluate", mock.Anything, mock.Anything).Return(test.err)
+			})
+
+			assert.Equal(t, test.err, service.Create(ctx, &todo))
+			assert.Equal(t, test.published, published)
+
+			repository.AssertExpectations(t)
+			scores.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCreate_validateError(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		service    = New(repository, scores, events.Nop{})
 		todo       = Todo{Title: ""}
 	)
 
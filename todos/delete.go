@@ -3,13 +3,16 @@ package todos
 import (
 	"context"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
 )
 
 type delete struct {
 	repository rel.Repository
+	publisher  events.Publisher
 }
 
 func (d delete) Delete(ctx context.Context, todo *Todo) {
 	d.repository.MustDelete(ctx, todo)
+	events.AfterCommit(ctx, d.publisher, todo.Change(EventTodoDeleted))
 }
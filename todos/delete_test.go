@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,15 +13,22 @@ func TestDelete(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository, nil)
-		todo       = Todo{ID: 1, Title: "Sleep"}
+		bus        = events.NewBus()
+		service    = New(repository, nil, bus)
+		todo       = Todo{ID: 1, UserID: 1, Title: "Sleep"}
+		published  []string
 	)
 
+	bus.Subscribe(func(ctx context.Context, event events.Event) {
+		published = append(published, event.Name)
+	})
+
 	repository.ExpectDelete().ForType("todos.Todo")
 
 	assert.NotPanics(t, func() {
 		service.Delete(ctx, &todo)
 	})
+	assert.Equal(t, []string{EventTodoDeleted}, published)
 
 	repository.AssertExpectations(t)
 }
@@ -0,0 +1,33 @@
+package todos
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	// sanitizer strips scripts, styles, event handlers and links that doesn't use a safe scheme.
+	sanitizer = newSanitizer()
+)
+
+func newSanitizer() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireNoFollowOnLinks(true)
+
+	return policy
+}
+
+// renderNotes converts markdown to sanitized html.
+func renderNotes(notes string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(notes), &buf); err != nil {
+		return "", err
+	}
+
+	return sanitizer.Sanitize(buf.String()), nil
+}
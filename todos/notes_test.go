@@ -0,0 +1,101 @@
+package todos
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderNotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes string
+		html  string
+	}{
+		{
+			name:  "empty",
+			notes: "",
+			html:  "",
+		},
+		{
+			name:  "emphasis",
+			notes: "**buy** _milk_",
+			html:  "<p><strong>buy</strong> <em>milk</em></p>\n",
+		},
+		{
+			name:  "list",
+			notes: "- eggs\n- milk",
+			html:  "<ul>\n<li>eggs</li>\n<li>milk</li>\n</ul>\n",
+		},
+		{
+			name:  "safe link",
+			notes: "[shop](https://example.com)",
+			html:  `<p><a href="https://example.com" rel="nofollow">shop</a></p>` + "\n",
+		},
+		{
+			name:  "mailto link",
+			notes: "[mail](mailto:me@example.com)",
+			html:  `<p><a href="mailto:me@example.com" rel="nofollow">mail</a></p>` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			html, err := renderNotes(test.notes)
+			assert.Nil(t, err)
+			assert.Equal(t, test.html, html)
+		})
+	}
+}
+
+var unsafeHTML = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)<\s*(script|style|iframe|object|embed|form|svg|math|details)\b`),
+	regexp.MustCompile(`(?i)<[^>]*\son\w+\s*=`),
+	regexp.MustCompile(`(?i)<[^>]*\sstyle\s*=`),
+	regexp.MustCompile(`(?i)<[^>]*\s(href|src|action|data)\s*=\s*["']?\s*(javascript|vbscript|data):`),
+}
+
+func TestRenderNotes_xss(t *testing.T) {
+	corpus := []string{
+		`<script>alert(1)</script>`,
+		`<SCRIPT SRC=https://example.com/xss.js></SCRIPT>`,
+		`<img src=x onerror=alert(1)>`,
+		`<svg onload=alert(1)>`,
+		`<body onload=alert(1)>`,
+		`<iframe src="javascript:alert(1)"></iframe>`,
+		`<style>body{background:url("javascript:alert(1)")}</style>`,
+		`<div style="background-image:url(javascript:alert(1))">x</div>`,
+		`<a href="javascript:alert(1)">x</a>`,
+		`<a href="JaVaScRiPt:alert(1)">x</a>`,
+		`<a href="&#106;avascript:alert(1)">x</a>`,
+		`<a href="vbscript:msgbox(1)">x</a>`,
+		`<a href="data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==">x</a>`,
+		`<a href="https://example.com" onclick="alert(1)">x</a>`,
+		`<object data="javascript:alert(1)"></object>`,
+		`<embed src="javascript:alert(1)">`,
+		`<form action="javascript:alert(1)"><input type=submit></form>`,
+		`<math><mtext><table><mglyph><style><img src=x onerror=alert(1)>`,
+		`<<script>script>alert(1)<</script>/script>`,
+		`[x](javascript:alert(1))`,
+		`[x](JAVASCRIPT:alert(1))`,
+		`[x](data:text/html,<script>alert(1)</script>)`,
+		`[x](vbscript:msgbox(1))`,
+		`![x](javascript:alert(1))`,
+		`![x](x" onerror="alert(1))`,
+		`[x]: javascript:alert(1)` + "\n\n[x]",
+		"<details open ontoggle=alert(1)>",
+		"```\n<script>alert(1)</script>\n```",
+	}
+
+	for _, input := range corpus {
+		t.Run(input, func(t *testing.T) {
+			html, err := renderNotes(input)
+			assert.Nil(t, err)
+
+			for _, pattern := range unsafeHTML {
+				assert.NotRegexp(t, pattern, html)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package todos
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Token types recognized by quick add parser.
+const (
+	TokenDate     = "date"
+	TokenTime     = "time"
+	TokenTag      = "tag"
+	TokenPriority = "priority"
+	TokenList     = "list"
+)
+
+// Token recognized from quick add text.
+type Token struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+var (
+	timeRegexp = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	weekdays   = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+	priorities = map[string]Priority{
+		"low": PriorityLow, "1": PriorityLow,
+		"medium": PriorityMedium, "med": PriorityMedium, "2": PriorityMedium, "!": PriorityMedium,
+		"high": PriorityHigh, "3": PriorityHigh, "!!": PriorityHigh,
+	}
+)
+
+type quick struct {
+	create
+	now func() time.Time
+}
+
+func (q quick) Quick(ctx context.Context, todo *Todo, text string) ([]Token, error) {
+	tokens := ParseQuick(todo, text, q.now())
+	return tokens, q.Create(ctx, todo)
+}
+
+// ParseQuick parses free text such as "Pay rent tomorrow 9am #home !high @personal" into todo.
+// Relative dates and times are resolved against now and its location.
+// Words that are not recognized as any token become the title.
+func ParseQuick(todo *Todo, text string, now time.Time) []Token {
+	var (
+		words  = strings.Fields(text)
+		title  []string
+		tokens []Token
+		date   *time.Time
+		clock  *time.Duration
+	)
+
+	for i := 0; i < len(words); i++ {
+		var (
+			word  = words[i]
+			lower = strings.ToLower(word)
+		)
+
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			todo.Tags = append(todo.Tags, word[1:])
+			tokens = append(tokens, Token{Type: TokenTag, Text: word})
+			continue
+		case len(word) > 1 && word[0] == '@':
+			todo.List = word[1:]
+			tokens = append(tokens, Token{Type: TokenList, Text: word})
+			continue
+		case word[0] == '!':
+			if priority, ok := priorities[lower[1:]]; ok {
+				todo.Priority = priority
+				tokens = append(tokens, Token{Type: TokenPriority, Text: word})
+				continue
+			}
+		}
+
+		if date == nil {
+			if d, n := parseDate(words[i:], now); n > 0 {
+				date = &d
+				tokens = append(tokens, Token{Type: TokenDate, Text: strings.Join(words[i:i+n], " ")})
+				i += n - 1
+				continue
+			}
+		}
+
+		if clock == nil {
+			if c, n := parseTime(words[i:]); n > 0 {
+				clock = &c
+				tokens = append(tokens, Token{Type: TokenTime, Text: strings.Join(words[i:i+n], " ")})
+				i += n - 1
+				continue
+			}
+		}
+
+		title = append(title, word)
+	}
+
+	todo.Title = strings.Join(title, " ")
+	todo.DueAt = resolveDue(date, clock, now)
+
+	return tokens
+}
+
+// parseDate returns the date and number of words consumed.
+func parseDate(words []string, now time.Time) (time.Time, int) {
+	var (
+		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		word  = strings.ToLower(words[0])
+		next  string
+	)
+
+	if len(words) > 1 {
+		next = strings.ToLower(words[1])
+	}
+
+	switch word {
+	case "today", "tonight":
+		return today, 1
+	case "tomorrow", "tmr":
+		return today.AddDate(0, 0, 1), 1
+	case "next":
+		if next == "week" {
+			return today.AddDate(0, 0, 7), 2
+		}
+
+		if weekday, ok := weekdays[next]; ok {
+			return nextWeekday(today, weekday).AddDate(0, 0, 7), 2
+		}
+	case "in":
+		if len(words) > 2 {
+			n, err := strconv.Atoi(next)
+			if err != nil || n < 0 {
+				return time.Time{}, 0
+			}
+
+			switch strings.TrimSuffix(strings.ToLower(words[2]), "s") {
+			case "day":
+				return today.AddDate(0, 0, n), 3
+			case "week":
+				return today.AddDate(0, 0, 7*n), 3
+			case "month":
+				return today.AddDate(0, n, 0), 3
+			}
+		}
+	case "on":
+		if weekday, ok := weekdays[next]; ok {
+			return nextWeekday(today, weekday), 2
+		}
+	}
+
+	if weekday, ok := weekdays[word]; ok {
+		return nextWeekday(today, weekday), 1
+	}
+
+	if d, err := time.ParseInLocation("2006-01-02", word, now.Location()); err == nil {
+		return d, 1
+	}
+
+	return time.Time{}, 0
+}
+
+// nextWeekday returns the upcoming weekday, today is included.
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	return today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)
+}
+
+// parseTime returns time of day and number of words consumed.
+func parseTime(words []string) (time.Duration, int) {
+	var (
+		word = strings.ToLower(words[0])
+	)
+
+	if word == "at" && len(words) > 1 {
+		if clock, n := parseTime(words[1:]); n > 0 {
+			return clock, n + 1
+		}
+
+		return 0, 0
+	}
+
+	switch word {
+	case "noon":
+		return 12 * time.Hour, 1
+	case "midnight":
+		return 0, 1
+	}
+
+	// require either minutes or am/pm, so plain numbers stay in the title.
+	match := timeRegexp.FindStringSubmatch(word)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return 0, 0
+	}
+
+	var (
+		hour, _   = strconv.Atoi(match[1])
+		minute, _ = strconv.Atoi(match[2])
+	)
+
+	switch match[3] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0
+		}
+
+		hour %= 12
+		if match[3] == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0
+		}
+	}
+
+	if minute > 59 {
+		return 0, 0
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, 1
+}
+
+// resolveDue combines date and time, a date without time is due by the end of the day,
+// and a time without date is due today or tomorrow if the time already passed.
+func resolveDue(date *time.Time, clock *time.Duration, now time.Time) *time.Time {
+	var due time.Time
+
+	switch {
+	case date != nil && clock != nil:
+		due = at(*date, *clock)
+	case date != nil:
+		due = date.AddDate(0, 0, 1).Add(-time.Second)
+	case clock != nil:
+		due = at(now, *clock)
+		if !due.After(now) {
+			due = due.AddDate(0, 0, 1)
+		}
+	default:
+		return nil
+	}
+
+	return &due
+}
+
+// at returns the given day at time of day clock.
+func at(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
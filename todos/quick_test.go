@@ -0,0 +1,182 @@
+package todos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores/scorestest"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Wednesday, 14 October 2026 15:04 UTC.
+var quickNow = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+
+func quickDate(year int, month time.Month, day, hour, min, sec int) *time.Time {
+	t := time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	return &t
+}
+
+func TestParseQuick(t *testing.T) {
+	tests := []struct {
+		text   string
+		todo   Todo
+		tokens []Token
+	}{
+		{
+			text: "Sleep",
+			todo: Todo{Title: "Sleep"},
+		},
+		{
+			text: "Pay rent tomorrow 9am #home !high",
+			todo: Todo{Title: "Pay rent", DueAt: quickDate(2026, 10, 15, 9, 0, 0), Tags: Tags{"home"}, Priority: PriorityHigh},
+			tokens: []Token{
+				{Type: TokenDate, Text: "tomorrow"},
+				{Type: TokenTime, Text: "9am"},
+				{Type: TokenTag, Text: "#home"},
+				{Type: TokenPriority, Text: "!high"},
+			},
+		},
+		{
+			text: "Call mom @family #phone #weekly !2",
+			todo: Todo{Title: "Call mom", List: "family", Tags: Tags{"phone", "weekly"}, Priority: PriorityMedium},
+			tokens: []Token{
+				{Type: TokenList, Text: "@family"},
+				{Type: TokenTag, Text: "#phone"},
+				{Type: TokenTag, Text: "#weekly"},
+				{Type: TokenPriority, Text: "!2"},
+			},
+		},
+		{
+			text:   "Submit report today",
+			todo:   Todo{Title: "Submit report", DueAt: quickDate(2026, 10, 14, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "today"}},
+		},
+		{
+			text:   "Standup at 9:30am",
+			todo:   Todo{Title: "Standup", DueAt: quickDate(2026, 10, 15, 9, 30, 0)},
+			tokens: []Token{{Type: TokenTime, Text: "at 9:30am"}},
+		},
+		{
+			text:   "Gym 18:00",
+			todo:   Todo{Title: "Gym", DueAt: quickDate(2026, 10, 14, 18, 0, 0)},
+			tokens: []Token{{Type: TokenTime, Text: "18:00"}},
+		},
+		{
+			text:   "Lunch noon",
+			todo:   Todo{Title: "Lunch", DueAt: quickDate(2026, 10, 15, 12, 0, 0)},
+			tokens: []Token{{Type: TokenTime, Text: "noon"}},
+		},
+		{
+			text: "Dinner Friday 7pm",
+			todo: Todo{Title: "Dinner", DueAt: quickDate(2026, 10, 16, 19, 0, 0)},
+			tokens: []Token{
+				{Type: TokenDate, Text: "Friday"},
+				{Type: TokenTime, Text: "7pm"},
+			},
+		},
+		{
+			text:   "Review on wed",
+			todo:   Todo{Title: "Review", DueAt: quickDate(2026, 10, 14, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "on wed"}},
+		},
+		{
+			text:   "Retro next wednesday",
+			todo:   Todo{Title: "Retro", DueAt: quickDate(2026, 10, 21, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "next wednesday"}},
+		},
+		{
+			text:   "Plan trip next week",
+			todo:   Todo{Title: "Plan trip", DueAt: quickDate(2026, 10, 21, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "next week"}},
+		},
+		{
+			text:   "Renew passport in 3 days",
+			todo:   Todo{Title: "Renew passport", DueAt: quickDate(2026, 10, 17, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "in 3 days"}},
+		},
+		{
+			text:   "Dentist in 2 weeks",
+			todo:   Todo{Title: "Dentist", DueAt: quickDate(2026, 10, 28, 23, 59, 59)},
+			tokens: []Token{{Type: TokenDate, Text: "in 2 weeks"}},
+		},
+		{
+			text: "Launch 2026-12-01 10:00 !!!",
+			todo: Todo{Title: "Launch", DueAt: quickDate(2026, 12, 1, 10, 0, 0), Priority: PriorityHigh},
+			tokens: []Token{
+				{Type: TokenDate, Text: "2026-12-01"},
+				{Type: TokenTime, Text: "10:00"},
+				{Type: TokenPriority, Text: "!!!"},
+			},
+		},
+		{
+			text: "Read 2 books in the park at home",
+			todo: Todo{Title: "Read 2 books in the park at home"},
+		},
+		{
+			text: "Shout ! and # and @ 13pm 25:00 10:75",
+			todo: Todo{Title: "Shout ! and # and @ 13pm 25:00 10:75"},
+		},
+		{
+			text: "!low #errand",
+			todo: Todo{Priority: PriorityLow, Tags: Tags{"errand"}},
+			tokens: []Token{
+				{Type: TokenPriority, Text: "!low"},
+				{Type: TokenTag, Text: "#errand"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			var (
+				todo   Todo
+				tokens = ParseQuick(&todo, test.text, quickNow)
+			)
+
+			assert.Equal(t, test.todo, todo)
+			assert.Equal(t, test.tokens, tokens)
+		})
+	}
+}
+
+func TestQuick(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scores     = &scorestest.Service{}
+		service    = New(repository, scores, events.Nop{}).(service)
+		todo       Todo
+	)
+
+	service.quick.now = func() time.Time { return quickNow }
+
+	repository.ExpectInsert().For(&Todo{Title: "Pay rent", DueAt: quickDate(2026, 10, 15, 9, 0, 0), Tags: Tags{"home"}})
+
+	tokens, err := service.Quick(ctx, &todo, "Pay rent tomorrow 9am #home")
+	assert.Nil(t, err)
+	assert.Len(t, tokens, 3)
+	assert.NotEmpty(t, todo.ID)
+
+	repository.AssertExpectations(t)
+	scores.AssertExpectations(t)
+}
+
+func TestQuick_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		scores     = &scorestest.Service{}
+		service    = New(repository, scores, events.Nop{})
+		todo       Todo
+	)
+
+	tokens, err := service.Quick(ctx, &todo, "tomorrow #home")
+	assert.Equal(t, ErrTodoTitleBlank, err)
+	assert.Len(t, tokens, 2)
+
+	repository.AssertExpectations(t)
+	scores.AssertExpectations(t)
+}
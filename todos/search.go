@@ -8,6 +8,7 @@ import (
 
 // Filter for search.
 type Filter struct {
+	UserID    int
 	Keyword   string
 	Completed *bool
 }
@@ -18,7 +19,7 @@ type search struct {
 
 func (s search) Search(ctx context.Context, todos *[]Todo, filter Filter) error {
 	var (
-		query = rel.Select().SortAsc("order")
+		query = rel.Select().Where(rel.Eq("user_id", filter.UserID)).SortAsc("order")
 	)
 
 	if filter.Keyword != "" {
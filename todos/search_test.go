@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -13,15 +14,15 @@ func TestSearch(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository, nil)
+		service    = New(repository, nil, events.Nop{})
 		todos      []Todo
 		completed  = false
-		filter     = Filter{Keyword: "Sleep", Completed: &completed}
+		filter     = Filter{UserID: 1, Keyword: "Sleep", Completed: &completed}
 		result     = []Todo{{ID: 1, Title: "Sleep"}}
 	)
 
 	repository.ExpectFindAll(
-		rel.Select().SortAsc("order").Where(rel.Like("title", "%Sleep%").AndEq("completed", false)),
+		rel.Select().Where(rel.Eq("user_id", 1)).SortAsc("order").Where(rel.Like("title", "%Sleep%")).Where(rel.Eq("completed", false)),
 	).Result(result)
 
 	assert.NotPanics(t, func() {
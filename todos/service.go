@@ -2,7 +2,9 @@ package todos
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/gin-example/scores"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
@@ -19,9 +21,10 @@ var (
 type Service interface {
 	Search(ctx context.Context, todos *[]Todo, filter Filter) error
 	Create(ctx context.Context, todo *Todo) error
+	Quick(ctx context.Context, todo *Todo, text string) ([]Token, error)
 	Update(ctx context.Context, todo *Todo, changes rel.Changeset) error
 	Delete(ctx context.Context, todo *Todo)
-	Clear(ctx context.Context)
+	Clear(ctx context.Context, userID int)
 }
 
 // beside embeding the struct, you can also declare the function directly on this struct.
@@ -29,6 +32,7 @@ type Service interface {
 type service struct {
 	search
 	create
+	quick
 	update
 	delete
 	clear
@@ -36,13 +40,16 @@ type service struct {
 
 var _ Service = (*service)(nil)
 
-// New Todos service.
-func New(repository rel.Repository, scores scores.Service) Service {
+// New Todos service, publisher is notified every time a todo is created, updated or deleted.
+func New(repository rel.Repository, scores scores.Service, publisher events.Publisher) Service {
+	create := create{repository: repository, scores: scores, publisher: publisher}
+
 	return service{
 		search: search{repository: repository},
-		create: create{repository: repository, scores: scores},
-		update: update{repository: repository, scores: scores},
-		delete: delete{repository: repository},
-		clear:  clear{repository: repository},
+		create: create,
+		quick:  quick{create: create, now: time.Now},
+		update: update{repository: repository, scores: scores, publisher: publisher},
+		delete: delete{repository: repository, publisher: publisher},
+		clear:  clear{repository: repository, publisher: publisher},
 	}
 }
@@ -0,0 +1,47 @@
+package todos
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Tags of a todo, stored as comma separated string.
+type Tags []string
+
+// Value implements driver.Valuer.
+func (t Tags) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+// Scan implements sql.Scanner.
+func (t *Tags) Scan(src interface{}) error {
+	var str string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("todos: cannot scan %T into Tags", src)
+	}
+
+	*t = nil
+	if str != "" {
+		*t = strings.Split(str, ",")
+	}
+
+	return nil
+}
+
+// Has returns true if tag exists.
+func (t Tags) Has(tag string) bool {
+	for i := range t {
+		if strings.EqualFold(t[i], tag) {
+			return true
+		}
+	}
+
+	return false
+}
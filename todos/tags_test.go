@@ -0,0 +1,43 @@
+package todos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags_Value(t *testing.T) {
+	value, err := Tags{"home", "work"}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "home,work", value)
+}
+
+func TestTags_Scan(t *testing.T) {
+	tests := []struct {
+		name string
+		src  interface{}
+		tags Tags
+		err  bool
+	}{
+		{name: "nil", src: nil},
+		{name: "empty", src: ""},
+		{name: "string", src: "home,work", tags: Tags{"home", "work"}},
+		{name: "bytes", src: []byte("home"), tags: Tags{"home"}},
+		{name: "invalid", src: 1, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tags Tags
+			err := tags.Scan(test.src)
+			assert.Equal(t, test.err, err != nil)
+			assert.Equal(t, test.tags, tags)
+		})
+	}
+}
+
+func TestTags_Has(t *testing.T) {
+	tags := Tags{"Home", "work"}
+	assert.True(t, tags.Has("home"))
+	assert.False(t, tags.Has("gym"))
+}
@@ -5,24 +5,118 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/gin-example/scores"
 )
 
 var (
-	// TodoURLPrefix to be returned when encoding todo.
-	TodoURLPrefix = os.Getenv("URL") + "todos/"
+	// BaseURL of the api, used to build url of todo.
+	BaseURL = os.Getenv("URL")
 	// ErrTodoTitleBlank validation error.
 	ErrTodoTitleBlank = errors.New("Title can't be blank")
+	// ErrTodoPriorityInvalid validation error.
+	ErrTodoPriorityInvalid = errors.New("Priority is invalid")
+)
+
+// Score events emitted by todos.
+const (
+	EventTodoCompleted   = "todo.completed"
+	EventTodoUncompleted = "todo.uncompleted"
+)
+
+// Change events published by todos after the change is committed, with the todo as the data.
+const (
+	EventTodoCreated = "todo.created"
+	EventTodoUpdated = "todo.updated"
+	EventTodoDeleted = "todo.deleted"
+	// EventTodosCleared is published without data when every todo of the user is deleted.
+	EventTodosCleared = "todos.cleared"
 )
 
+// SourceTodo is the source type of points earned from a todo.
+const SourceTodo = "todo"
+
+// Priority of a todo.
+type Priority int
+
+// Available priorities.
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var priorityNames = []string{"none", "low", "medium", "high"}
+
+// String returns name of the priority.
+func (p Priority) String() string {
+	if p < PriorityNone || p > PriorityHigh {
+		return strconv.Itoa(int(p))
+	}
+
+	return priorityNames[p]
+}
+
+// UnmarshalJSON accepts priority as number or by its name.
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var number int
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+
+		*p = Priority(number)
+		return nil
+	}
+
+	for i := range priorityNames {
+		if priorityNames[i] == name {
+			*p = Priority(i)
+			return nil
+		}
+	}
+
+	return ErrTodoPriorityInvalid
+}
+
+// TodoURLPrefix to be returned when encoding todo of an api version, empty version is used by unversioned routes.
+func TodoURLPrefix(version string) string {
+	if version == "" {
+		return BaseURL + "todos/"
+	}
+
+	return BaseURL + version + "/todos/"
+}
+
 // Todo respresent a record stored in todos table.
 type Todo struct {
-	ID        uint      `json:"id"`
-	Title     string    `json:"title"`
-	Order     int       `json:"order"`
-	Completed bool      `json:"completed"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint       `json:"id"`
+	UserID    int        `json:"user_id"`
+	Title     string     `json:"title"`
+	Notes     string     `json:"notes"`
+	NotesHTML string     `json:"notes_html,omitempty" db:"-"`
+	Order     int        `json:"order"`
+	Completed bool       `json:"completed"`
+	DueAt     *time.Time `json:"due_at"`
+	Priority  Priority   `json:"priority"`
+	List      string     `json:"list"`
+	Tags      Tags       `json:"tags"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Change event of the todo.
+func (t Todo) Change(name string) events.Event {
+	return events.Event{Name: name, UserID: t.UserID, Data: t}
 }
 
 // Validate todo.
@@ -31,12 +125,42 @@ func (t Todo) Validate() error {
 	switch {
 	case len(t.Title) == 0:
 		err = ErrTodoTitleBlank
+	case t.Priority < PriorityNone || t.Priority > PriorityHigh:
+		err = ErrTodoPriorityInvalid
 	}
 
 	return err
 }
 
-// MarshalJSON implement custom marshaller to marshal url.
+// Overdue returns true if todo has due date that already passed.
+func (t Todo) Overdue(now time.Time) bool {
+	return t.DueAt != nil && now.After(*t.DueAt)
+}
+
+// Event for score evaluation.
+func (t Todo) Event(name string, now time.Time) scores.Event {
+	return scores.Event{
+		Name:     name,
+		UserID:   t.UserID,
+		Priority: int(t.Priority),
+		Overdue:  t.Overdue(now),
+		Tags:     t.Tags,
+		Source:   scores.Source{Type: SourceTodo, ID: int(t.ID)},
+	}
+}
+
+// RenderNotes renders markdown notes as sanitized html into NotesHTML.
+func (t *Todo) RenderNotes() error {
+	html, err := renderNotes(t.Notes)
+	if err != nil {
+		return err
+	}
+
+	t.NotesHTML = html
+	return nil
+}
+
+// MarshalJSON implement custom marshaller to marshal url of unversioned routes.
 func (t Todo) MarshalJSON() ([]byte, error) {
 	type Alias Todo
 
@@ -45,6 +169,6 @@ func (t Todo) MarshalJSON() ([]byte, error) {
 		URL string `json:"url"`
 	}{
 		Alias: Alias(t),
-		URL:   fmt.Sprint(TodoURLPrefix, t.ID),
+		URL:   fmt.Sprint(TodoURLPrefix(""), t.ID),
 	})
 }
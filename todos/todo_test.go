@@ -2,13 +2,17 @@ package todos
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func init() {
-	TodoURLPrefix = "http://localhost:3000/"
+	BaseURL = "http://localhost:3000/"
 }
 
 func TestTodo_Validate(t *testing.T) {
@@ -18,12 +22,47 @@ func TestTodo_Validate(t *testing.T) {
 		assert.Equal(t, ErrTodoTitleBlank, todo.Validate())
 	})
 
+	t.Run("priority is invalid", func(t *testing.T) {
+		todo := Todo{Title: "Sleep", Priority: PriorityHigh + 1}
+		assert.Equal(t, ErrTodoPriorityInvalid, todo.Validate())
+	})
+
 	t.Run("valid", func(t *testing.T) {
 		todo.Title = "Sleep"
 		assert.Nil(t, todo.Validate())
 	})
 }
 
+func TestTodo_Event(t *testing.T) {
+	var (
+		now  = time.Date(2026, 10, 14, 15, 4, 0, 0, time.UTC)
+		due  = now.Add(-time.Hour)
+		todo = Todo{ID: 2, UserID: 1, Title: "Sleep", Priority: PriorityHigh, Tags: Tags{"home"}, DueAt: &due}
+	)
+
+	assert.Equal(t, scores.Event{
+		Name:     EventTodoCompleted,
+		UserID:   1,
+		Priority: 3,
+		Overdue:  true,
+		Tags:     []string{"home"},
+		Source:   scores.Source{Type: "todo", ID: 2},
+	}, todo.Event(EventTodoCompleted, now))
+
+	assert.False(t, todo.Overdue(due))
+	assert.False(t, Todo{}.Overdue(now))
+}
+
+func TestTodo_RenderNotes(t *testing.T) {
+	var (
+		todo = Todo{Notes: "**Sleep** <script>alert(1)</script>"}
+	)
+
+	assert.Nil(t, todo.RenderNotes())
+	assert.Equal(t, "**Sleep** <script>alert(1)</script>", todo.Notes)
+	assert.Equal(t, "<p><strong>Sleep</strong> alert(1)</p>\n", todo.NotesHTML)
+}
+
 func TestTodo_MarshalJSON(t *testing.T) {
 	var (
 		todo = Todo{
@@ -37,11 +76,69 @@ func TestTodo_MarshalJSON(t *testing.T) {
 	assert.Nil(t, err)
 	assert.JSONEq(t, `{
 		"id": 1,
+		"user_id": 0,
 		"title": "Sleep",
+		"notes": "",
 		"completed": true,
 		"order": 0,
-		"url": "http://localhost:3000/1",
+		"due_at": null,
+		"priority": 0,
+		"list": "",
+		"tags": null,
+		"url": "http://localhost:3000/todos/1",
 		"created_at": "0001-01-01T00:00:00Z",
 		"updated_at": "0001-01-01T00:00:00Z"
 	}`, string(encoded))
 }
+
+func TestTodoURLPrefix(t *testing.T) {
+	assert.Equal(t, "http://localhost:3000/todos/", TodoURLPrefix(""))
+	assert.Equal(t, "http://localhost:3000/v1/todos/", TodoURLPrefix("v1"))
+}
+
+func TestPriority_String(t *testing.T) {
+	assert.Equal(t, "none", PriorityNone.String())
+	assert.Equal(t, "high", PriorityHigh.String())
+	assert.Equal(t, "4", (PriorityHigh + 1).String())
+}
+
+func TestPriority_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		priority Priority
+		err      error
+	}{
+		{
+			name:     "number",
+			data:     `{"priority": 2}`,
+			priority: PriorityMedium,
+		},
+		{
+			name:     "name",
+			data:     `{"priority": "high"}`,
+			priority: PriorityHigh,
+		},
+		{
+			name:     "null",
+			data:     `{"priority": null}`,
+			priority: PriorityLow,
+		},
+		{
+			name:     "unknown name",
+			data:     `{"priority": "urgent"}`,
+			priority: PriorityLow,
+			err:      ErrTodoPriorityInvalid,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			todo := Todo{Priority: PriorityLow}
+
+			err := json.Unmarshal([]byte(test.data), &todo)
+			assert.Equal(t, test.priority, todo.Priority)
+			assert.True(t, errors.Is(err, test.err))
+		})
+	}
+}
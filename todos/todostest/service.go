@@ -16,9 +16,9 @@ type Service struct {
 	mock.Mock
 }
 
-// Clear provides a mock function with given fields: ctx
-func (_m *Service) Clear(ctx context.Context) {
-	_m.Called(ctx)
+// Clear provides a mock function with given fields: ctx, userID
+func (_m *Service) Clear(ctx context.Context, userID int) {
+	_m.Called(ctx, userID)
 }
 
 // Create provides a mock function with given fields: ctx, todo
@@ -40,6 +40,29 @@ func (_m *Service) Delete(ctx context.Context, todo *todos.Todo) {
 	_m.Called(ctx, todo)
 }
 
+// Quick provides a mock function with given fields: ctx, todo, text
+func (_m *Service) Quick(ctx context.Context, todo *todos.Todo, text string) ([]todos.Token, error) {
+	ret := _m.Called(ctx, todo, text)
+
+	var r0 []todos.Token
+	if rf, ok := ret.Get(0).(func(context.Context, *todos.Todo, string) []todos.Token); ok {
+		r0 = rf(ctx, todo, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]todos.Token)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *todos.Todo, string) error); ok {
+		r1 = rf(ctx, todo, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Search provides a mock function with given fields: ctx, _a1, filter
 func (_m *Service) Search(ctx context.Context, _a1 *[]todos.Todo, filter todos.Filter) error {
 	ret := _m.Called(ctx, _a1, filter)
@@ -42,6 +42,17 @@ func MockCreate(result todos.Todo, err error) MockFunc {
 	}
 }
 
+// MockQuick util.
+func MockQuick(text string, result todos.Todo, tokens []todos.Token, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Quick", mock.Anything, mock.Anything, text).
+			Return(tokens, err).
+			Run(func(args mock.Arguments) {
+				*args.Get(1).(*todos.Todo) = result
+			})
+	}
+}
+
 // MockUpdate util.
 func MockUpdate(result todos.Todo, err error) MockFunc {
 	return func(service *Service) {
@@ -58,9 +69,9 @@ func MockUpdate(result todos.Todo, err error) MockFunc {
 }
 
 // MockClear util.
-func MockClear() MockFunc {
+func MockClear(userID int) MockFunc {
 	return func(service *Service) {
-		service.On("Clear", mock.Anything)
+		service.On("Clear", mock.Anything, userID)
 	}
 }
 
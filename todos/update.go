@@ -2,7 +2,9 @@ package todos
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/gin-example/scores"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
@@ -11,6 +13,7 @@ import (
 type update struct {
 	repository rel.Repository
 	scores     scores.Service
+	publisher  events.Publisher
 }
 
 func (u update) Update(ctx context.Context, todo *Todo, changes rel.Changeset) error {
@@ -19,19 +22,25 @@ func (u update) Update(ctx context.Context, todo *Todo, changes rel.Changeset) e
 		return err
 	}
 
-	// update score if completed is changed.
+	// update score if completed is changed, retried as a whole when deadlocked by concurrent earn.
 	if changes.FieldChanged("completed") {
-		return u.repository.Transaction(ctx, func(ctx context.Context) error {
-			u.repository.MustUpdate(ctx, todo, changes)
-
-			if todo.Completed {
-				return u.scores.Earn(ctx, "todo completed", 1)
-			}
-
-			return u.scores.Earn(ctx, "todo uncompleted", -2)
+		return scores.Retry(ctx, func(ctx context.Context) error {
+			return events.Commit(ctx, func(ctx context.Context) error {
+				return u.repository.Transaction(ctx, func(ctx context.Context) error {
+					u.repository.MustUpdate(ctx, todo, changes)
+					events.AfterCommit(ctx, u.publisher, todo.Change(EventTodoUpdated))
+
+					if todo.Completed {
+						return u.scores.Evaluate(ctx, todo.Event(EventTodoCompleted, time.Now()))
+					}
+
+					return u.scores.Evaluate(ctx, todo.Event(EventTodoUncompleted, time.Now()))
+				})
+			})
 		})
 	}
 
 	u.repository.MustUpdate(ctx, todo, changes)
+	events.AfterCommit(ctx, u.publisher, todo.Change(EventTodoUpdated))
 	return nil
 }
@@ -3,7 +3,9 @@ package todos
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/go-rel/gin-example/events"
 	"github.com/go-rel/gin-example/scores/scorestest"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
@@ -16,8 +18,8 @@ func TestUpdate(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{ID: 1, Title: "Sleep"}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{ID: 1, UserID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
 
@@ -37,15 +39,15 @@ func TestUpdate_completed(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{ID: 1, Title: "Sleep"}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{ID: 1, UserID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
 
 	todo.Completed = true
 
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo completed", 1).Return(nil)
+		scores.On("Evaluate", mock.Anything, todo.Event(EventTodoCompleted, time.Now())).Return(nil)
 		repository.ExpectUpdate(changes).ForType("todos.Todo")
 	})
 
@@ -61,15 +63,15 @@ func TestUpdate_uncompleted(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{ID: 1, Title: "Sleep", Completed: true}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{ID: 1, UserID: 1, Title: "Sleep", Completed: true}
 		changes    = rel.NewChangeset(&todo)
 	)
 
 	todo.Completed = false
 
 	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo uncompleted", -2).Return(nil)
+		scores.On("Evaluate", mock.Anything, todo.Event(EventTodoUncompleted, time.Now())).Return(nil)
 		repository.ExpectUpdate(changes).ForType("todos.Todo")
 	})
 
@@ -85,8 +87,8 @@ func TestUpdate_validateError(t *testing.T) {
 		ctx        = context.TODO()
 		repository = reltest.New()
 		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
-		todo       = Todo{ID: 1, Title: "Sleep"}
+		service    = New(repository, scores, events.Nop{})
+		todo       = Todo{ID: 1, UserID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
 
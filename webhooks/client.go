@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrAddressBlocked is recorded on an attempt to an url that resolves to a loopback, private, link-local or unspecified address.
+var ErrAddressBlocked = errors.New("URL resolves to a blocked address")
+
+// NewClient for the service, it only connects to public addresses and doesn't follow redirects.
+// Addresses are checked once they're resolved, so a public host name that resolves to a private address is blocked too.
+// Redirect is responded as is, which fails the attempt since it's not 2xx.
+func NewClient() *http.Client {
+	var (
+		dialer    = &net.Dialer{Timeout: DeliveryTimeout, KeepAlive: 30 * time.Second, Control: control}
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	)
+
+	// proxy would be dialed instead of the receiver, leaving the receiver's address unchecked.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   DeliveryTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// control rejects the connection before it's made when the resolved address is blocked.
+func control(network string, address string, c syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil || blocked(addr.Addr()) {
+		return ErrAddressBlocked
+	}
+
+	return nil
+}
+
+// blocked returns true if ip is not a public address.
+func blocked(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+// blockedHost returns true if host is a blocked ip literal or localhost, other host names are checked once resolved.
+func blockedHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip, err := netip.ParseAddr(host)
+	return err == nil && blocked(ip)
+}
@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient_blocked(t *testing.T) {
+	var (
+		requested bool
+		server    = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = true
+		}))
+	)
+
+	defer server.Close()
+
+	// the test server listens on loopback.
+	_, err := NewClient().Post(server.URL, "application/json", nil)
+	assert.True(t, errors.Is(err, ErrAddressBlocked))
+	assert.False(t, requested)
+}
+
+func TestNewClient_redirect(t *testing.T) {
+	var (
+		client = NewClient()
+		req    = httptest.NewRequest(http.MethodPost, "https://example.com/hooks", nil)
+	)
+
+	assert.Equal(t, http.ErrUseLastResponse, client.CheckRedirect(req, []*http.Request{req}))
+}
+
+func TestBlocked(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{ip: "93.184.216.34"},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946"},
+		{ip: "127.0.0.1", blocked: true},
+		{ip: "::1", blocked: true},
+		{ip: "10.1.2.3", blocked: true},
+		{ip: "172.16.0.1", blocked: true},
+		{ip: "192.168.1.1", blocked: true},
+		{ip: "fd00::1", blocked: true},
+		{ip: "169.254.169.254", blocked: true},
+		{ip: "fe80::1", blocked: true},
+		{ip: "0.0.0.0", blocked: true},
+		{ip: "::", blocked: true},
+		{ip: "::ffff:127.0.0.1", blocked: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ip, func(t *testing.T) {
+			assert.Equal(t, test.blocked, blocked(netip.MustParseAddr(test.ip)))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+type create struct {
+	repository rel.Repository
+}
+
+// Create enabled webhook, a random secret is generated when it's not chosen by the user.
+func (c create) Create(ctx context.Context, webhook *Webhook) error {
+	if webhook.Secret == "" {
+		webhook.Secret = newSecret()
+	}
+
+	if err := webhook.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	webhook.Enabled = true
+	webhook.Failures = 0
+	webhook.DisabledAt = nil
+
+	return c.repository.Insert(ctx, webhook)
+}
+
+func newSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
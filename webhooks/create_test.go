@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		webhook    = Webhook{UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Failures: 3}
+	)
+
+	repository.ExpectInsert().For(&Webhook{UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true})
+
+	assert.Nil(t, service.Create(ctx, &webhook))
+	assert.Equal(t, 1, webhook.ID)
+	assert.True(t, webhook.Enabled)
+	assert.Zero(t, webhook.Failures)
+
+	repository.AssertExpectations(t)
+}
+
+func TestCreate_generatedSecret(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		webhook    = Webhook{UserID: 1, URL: "https://example.com/hooks"}
+	)
+
+	repository.ExpectInsert().ForType("webhooks.Webhook")
+
+	assert.Nil(t, service.Create(ctx, &webhook))
+	assert.Len(t, webhook.Secret, 64)
+
+	repository.AssertExpectations(t)
+}
+
+func TestCreate_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		webhook    = Webhook{UserID: 1, URL: "example.com/hooks"}
+	)
+
+	assert.Equal(t, ErrWebhookURLInvalid, service.Create(ctx, &webhook))
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+)
+
+type delete struct {
+	repository rel.Repository
+}
+
+// Delete webhook, its deliveries are deleted by the database.
+func (d delete) Delete(ctx context.Context, webhook *Webhook) {
+	d.repository.MustDelete(ctx, webhook)
+}
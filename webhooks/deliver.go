@@ -0,0 +1,232 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+// Headers of a delivery request.
+const (
+	// HeaderEvent is the name of the delivered event.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderDelivery is the id of the delivery, it's the same for every attempt of a delivery.
+	HeaderDelivery = "X-Webhook-Delivery"
+	// HeaderTimestamp is the unix time of the attempt, receivers should reject a delivery with a stale timestamp.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is the signature of the payload, see Sign.
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// MaxAttempts of a delivery before it's given up.
+	MaxAttempts = 8
+	// MaxFailures is the number of consecutive failed attempts of a webhook before it's disabled.
+	MaxFailures = 20
+	// RetryBackoff is the wait before the second attempt, it's doubled after every failed attempt.
+	RetryBackoff = 30 * time.Second
+	// DeliveryTimeout of an attempt, used by the http client of the service.
+	DeliveryTimeout = 10 * time.Second
+	// DeliveryBatch is the number of due deliveries attempted by every call to Deliver.
+	DeliveryBatch = 100
+	// DeliveryConcurrency is the number of deliveries attempted at the same time, so a slow receiver doesn't hold up the others.
+	DeliveryConcurrency = 10
+	// deliveryLease keeps an attempt in progress from being picked up by other workers, must be longer than DeliveryTimeout.
+	deliveryLease = time.Minute
+)
+
+// ErrWebhookDisabled is recorded on pending deliveries of a disabled webhook.
+var ErrWebhookDisabled = errors.New("Webhook is disabled")
+
+// Sign payload sent at timestamp using HMAC-SHA256 of "{timestamp}.{payload}".
+// Receivers verify a delivery by comparing HeaderSignature with the signature computed using their copy of the secret.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type deliver struct {
+	repository rel.Repository
+	client     *http.Client
+	now        func() time.Time
+}
+
+// Deliver attempts deliveries that are due, oldest first, DeliveryConcurrency at a time.
+// Failed attempt is retried with exponential backoff until MaxAttempts.
+// Every due delivery is attempted even when another attempt errors, the error of the oldest one is returned.
+func (d deliver) Deliver(ctx context.Context) error {
+	var (
+		deliveries []Delivery
+		query      = where.Eq("status", DeliveryPending).AndLte("next_attempt_at", d.now())
+	)
+
+	if err := d.repository.FindAll(ctx, &deliveries, query, rel.SortAsc("next_attempt_at"), rel.SortAsc("id"), rel.Limit(DeliveryBatch)); err != nil {
+		return err
+	}
+
+	var (
+		wg      sync.WaitGroup
+		pending = make(chan int)
+		errs    = make([]error, len(deliveries))
+	)
+
+	wg.Add(DeliveryConcurrency)
+	for w := 0; w < DeliveryConcurrency; w++ {
+		go func() {
+			defer wg.Done()
+
+			for i := range pending {
+				errs[i] = d.attempt(ctx, &deliveries[i])
+			}
+		}()
+	}
+
+	for i := range deliveries {
+		pending <- i
+	}
+
+	close(pending)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attempt delivery once it's claimed, only one of concurrent workers claims the delivery.
+func (d deliver) attempt(ctx context.Context, delivery *Delivery) error {
+	var (
+		webhook Webhook
+		now     = d.now()
+		lease   = now.Add(deliveryLease)
+		query   = rel.From("deliveries").
+			Where(where.Eq("id", delivery.ID), where.Eq("status", DeliveryPending), where.Lte("next_attempt_at", now))
+	)
+
+	if updated, err := d.repository.UpdateAny(ctx, query, rel.Set("next_attempt_at", lease)); err != nil || updated == 0 {
+		return err
+	}
+
+	if err := d.repository.Find(ctx, &webhook, where.Eq("id", delivery.WebhookID)); err != nil {
+		return err
+	}
+
+	if !webhook.Enabled {
+		delivery.Status = DeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = ErrWebhookDisabled.Error()
+		return d.repository.Update(ctx, delivery)
+	}
+
+	status, err := d.send(ctx, webhook, *delivery, now)
+
+	// attempt interrupted by shutdown is attempted again once its lease expires.
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.Error = ""
+
+	if err == nil {
+		delivery.Status = DeliverySucceeded
+		delivery.NextAttemptAt = nil
+	} else {
+		logger.Warn("webhook delivery error", zap.Int("webhook_id", webhook.ID), zap.Int("delivery_id", delivery.ID), zap.Error(err))
+
+		next := now.Add(backoff(delivery.Attempts))
+		delivery.Error = err.Error()
+		delivery.NextAttemptAt = &next
+
+		if delivery.Attempts >= MaxAttempts {
+			delivery.Status = DeliveryFailed
+			delivery.NextAttemptAt = nil
+		}
+	}
+
+	if err := d.repository.Update(ctx, delivery); err != nil {
+		return err
+	}
+
+	return d.track(ctx, webhook, err == nil, now)
+}
+
+// track consecutive failures of the webhook, it's disabled once failures reach MaxFailures.
+func (d deliver) track(ctx context.Context, webhook Webhook, succeeded bool, now time.Time) error {
+	var (
+		query   = rel.From("webhooks").Where(where.Eq("id", webhook.ID))
+		mutates []rel.Mutate
+	)
+
+	switch {
+	case succeeded && webhook.Failures == 0:
+		return nil
+	case succeeded:
+		mutates = append(mutates, rel.Set("failures", 0))
+	case webhook.Failures+1 >= MaxFailures:
+		logger.Warn("webhook disabled", zap.Int("webhook_id", webhook.ID), zap.Int("user_id", webhook.UserID))
+		mutates = append(mutates, rel.Inc("failures"), rel.Set("enabled", false), rel.Set("disabled_at", now))
+	default:
+		mutates = append(mutates, rel.Inc("failures"))
+	}
+
+	_, err := d.repository.UpdateAny(ctx, query, append(mutates, rel.Set("updated_at", now))...)
+	return err
+}
+
+// send payload of the delivery to the webhook, any response other than 2xx is an error.
+func (d deliver) send(ctx context.Context, webhook Webhook, delivery Delivery, now time.Time) (int, error) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+	// drain the body, so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("Receiver responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff before the next attempt after the number of failed attempts.
+func backoff(attempts int) time.Duration {
+	return RetryBackoff << (attempts - 1)
+}
@@ -0,0 +1,322 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	webhookSecret  = "0123456789abcdef"
+	webhookPayload = `{"name":"todo.created","user_id":1,"data":{"id":1},"at":"2026-10-19T09:00:00Z"}`
+)
+
+// receiver responds with status and records every request it receives.
+// Receiver with block channel doesn't respond until the channel is closed.
+type receiver struct {
+	*httptest.Server
+	status   int
+	block    chan struct{}
+	mutex    sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mutex.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, string(body))
+		r.mutex.Unlock()
+
+		if r.block != nil {
+			<-r.block
+		}
+
+		w.WriteHeader(r.status)
+	}))
+
+	t.Cleanup(r.Close)
+	return r
+}
+
+func newDeliverService(repository *reltest.Repository, receiver *receiver) service {
+	service := newService(repository)
+	service.deliver.client = receiver.Client()
+	return service
+}
+
+func expectDue(repository *reltest.Repository, deliveries ...Delivery) {
+	query := where.Eq("status", DeliveryPending).AndLte("next_attempt_at", webhookNow)
+	repository.ExpectFindAll(query, rel.SortAsc("next_attempt_at"), rel.SortAsc("id"), rel.Limit(DeliveryBatch)).Result(deliveries)
+}
+
+func expectClaim(repository *reltest.Repository, delivery Delivery) *reltest.MockUpdateAny {
+	query := rel.From("deliveries").Where(where.Eq("id", delivery.ID), where.Eq("status", DeliveryPending), where.Lte("next_attempt_at", webhookNow))
+	return repository.ExpectUpdateAny(query, rel.Set("next_attempt_at", webhookNow.Add(deliveryLease)))
+}
+
+func expectTrack(repository *reltest.Repository, webhook Webhook, mutates ...rel.Mutate) *reltest.MockUpdateAny {
+	query := rel.From("webhooks").Where(where.Eq("id", webhook.ID))
+	return repository.ExpectUpdateAny(query, append(mutates, rel.Set("updated_at", webhookNow))...)
+}
+
+func TestDeliver(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusNoContent)
+		service    = newDeliverService(repository, receiver)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: receiver.URL + "/hooks", Secret: webhookSecret, Enabled: true}
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow}
+		timestamp  = strconv.FormatInt(webhookNow.Unix(), 10)
+	)
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).UpdatedCount(1)
+	repository.ExpectFind(where.Eq("id", 1)).Result(webhook)
+	repository.ExpectUpdate().For(&Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliverySucceeded, Attempts: 1, ResponseStatus: 204})
+
+	assert.Nil(t, service.Deliver(ctx))
+
+	assert.Len(t, receiver.requests, 1)
+	req := receiver.requests[0]
+	assert.Equal(t, "/hooks", req.URL.Path)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "todo.created", req.Header.Get(HeaderEvent))
+	assert.Equal(t, "2", req.Header.Get(HeaderDelivery))
+	assert.Equal(t, timestamp, req.Header.Get(HeaderTimestamp))
+	assert.Equal(t, Sign(webhookSecret, timestamp, []byte(webhookPayload)), req.Header.Get(HeaderSignature))
+	assert.Equal(t, webhookPayload, receiver.bodies[0])
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_concurrently(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		slow       = newReceiver(t, http.StatusOK)
+		fast       = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, fast)
+		done       = make(chan error)
+		webhooks   = []Webhook{
+			{ID: 1, UserID: 1, URL: slow.URL, Secret: webhookSecret, Enabled: true},
+			{ID: 3, UserID: 1, URL: fast.URL, Secret: webhookSecret, Enabled: true},
+		}
+		deliveries = []Delivery{
+			{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow},
+			{ID: 4, WebhookID: 3, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow},
+		}
+	)
+
+	slow.block = make(chan struct{})
+
+	expectDue(repository, deliveries...)
+	for i := range deliveries {
+		expectClaim(repository, deliveries[i]).UpdatedCount(1)
+		repository.ExpectFind(where.Eq("id", webhooks[i].ID)).Result(webhooks[i])
+		repository.ExpectUpdate().For(&Delivery{ID: deliveries[i].ID, WebhookID: webhooks[i].ID, Event: "todo.created", Payload: webhookPayload, Status: DeliverySucceeded, Attempts: 1, ResponseStatus: 200})
+	}
+
+	go func() {
+		done <- service.Deliver(ctx)
+	}()
+
+	// the later delivery is sent while the receiver of the older one hasn't responded.
+	assert.Eventually(t, func() bool {
+		fast.mutex.Lock()
+		defer fast.mutex.Unlock()
+		return len(fast.requests) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	close(slow.block)
+	assert.Nil(t, <-done)
+	assert.Len(t, slow.requests, 1)
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_resetFailures(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, receiver)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: receiver.URL, Secret: webhookSecret, Enabled: true, Failures: 3}
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, Attempts: 3, NextAttemptAt: &webhookNow}
+	)
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).UpdatedCount(1)
+	repository.ExpectFind(where.Eq("id", 1)).Result(webhook)
+	repository.ExpectUpdate().For(&Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliverySucceeded, Attempts: 4, ResponseStatus: 200})
+	expectTrack(repository, webhook, rel.Set("failures", 0)).UpdatedCount(1)
+
+	assert.Nil(t, service.Deliver(ctx))
+	assert.Len(t, receiver.requests, 1)
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_failed(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		failures int
+		result   Delivery
+		mutates  []rel.Mutate
+	}{
+		{
+			name:     "retried with backoff",
+			attempts: 2,
+			result:   Delivery{Status: DeliveryPending, Attempts: 3, NextAttemptAt: timePtr(webhookNow.Add(2 * time.Minute))},
+			mutates:  []rel.Mutate{rel.Inc("failures")},
+		},
+		{
+			name:     "given up after max attempts",
+			attempts: MaxAttempts - 1,
+			result:   Delivery{Status: DeliveryFailed, Attempts: MaxAttempts},
+			mutates:  []rel.Mutate{rel.Inc("failures")},
+		},
+		{
+			name:     "webhook disabled after max failures",
+			failures: MaxFailures - 1,
+			result:   Delivery{Status: DeliveryPending, Attempts: 1, NextAttemptAt: timePtr(webhookNow.Add(RetryBackoff))},
+			mutates:  []rel.Mutate{rel.Inc("failures"), rel.Set("enabled", false), rel.Set("disabled_at", webhookNow)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				receiver   = newReceiver(t, http.StatusInternalServerError)
+				service    = newDeliverService(repository, receiver)
+				webhook    = Webhook{ID: 1, UserID: 1, URL: receiver.URL, Secret: webhookSecret, Enabled: true, Failures: test.failures}
+				delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, Attempts: test.attempts, NextAttemptAt: &webhookNow}
+				result     = delivery
+			)
+
+			result.Status = test.result.Status
+			result.Attempts = test.result.Attempts
+			result.NextAttemptAt = test.result.NextAttemptAt
+			result.ResponseStatus = http.StatusInternalServerError
+			result.Error = "Receiver responded with status 500"
+
+			expectDue(repository, delivery)
+			expectClaim(repository, delivery).UpdatedCount(1)
+			repository.ExpectFind(where.Eq("id", 1)).Result(webhook)
+			repository.ExpectUpdate().For(&result)
+			expectTrack(repository, webhook, test.mutates...).UpdatedCount(1)
+
+			assert.Nil(t, service.Deliver(ctx))
+			assert.Len(t, receiver.requests, 1)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeliver_unreachable(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, receiver)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: receiver.URL, Secret: webhookSecret, Enabled: true}
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow}
+	)
+
+	receiver.Close()
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).UpdatedCount(1)
+	repository.ExpectFind(where.Eq("id", 1)).Result(webhook)
+	repository.ExpectUpdate().ForType("*webhooks.Delivery")
+	expectTrack(repository, webhook, rel.Inc("failures")).UpdatedCount(1)
+
+	assert.Nil(t, service.Deliver(ctx))
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_webhookDisabled(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, receiver)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: receiver.URL, Secret: webhookSecret, Failures: MaxFailures, DisabledAt: &webhookNow}
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow}
+	)
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).UpdatedCount(1)
+	repository.ExpectFind(where.Eq("id", 1)).Result(webhook)
+	repository.ExpectUpdate().For(&Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryFailed, Error: "Webhook is disabled"})
+
+	assert.Nil(t, service.Deliver(ctx))
+	assert.Empty(t, receiver.requests)
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_claimedByOtherWorker(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, receiver)
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow}
+	)
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).UpdatedCount(0)
+
+	assert.Nil(t, service.Deliver(ctx))
+	assert.Empty(t, receiver.requests)
+
+	repository.AssertExpectations(t)
+}
+
+func TestDeliver_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		receiver   = newReceiver(t, http.StatusOK)
+		service    = newDeliverService(repository, receiver)
+		delivery   = Delivery{ID: 2, WebhookID: 1, Event: "todo.created", Payload: webhookPayload, Status: DeliveryPending, NextAttemptAt: &webhookNow}
+	)
+
+	expectDue(repository, delivery)
+	expectClaim(repository, delivery).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Deliver(ctx))
+	assert.Empty(t, receiver.requests)
+
+	repository.AssertExpectations(t)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1792400400.{}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	assert.Equal(t, "sha256=57a925fe266b8c83b7247f150fee670e05729f6a3c2f86718f206bed78b79604", Sign(webhookSecret, "1792400400", []byte("{}")))
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
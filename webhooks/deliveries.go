@@ -0,0 +1,20 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+// DeliveryLogSize is the number of latest deliveries listed in the delivery log.
+const DeliveryLogSize = 100
+
+type deliveries struct {
+	repository rel.Repository
+}
+
+// Deliveries lists the latest deliveries of the webhook, newest first.
+func (d deliveries) Deliveries(ctx context.Context, deliveries *[]Delivery, webhook Webhook) error {
+	return d.repository.FindAll(ctx, deliveries, where.Eq("webhook_id", webhook.ID), rel.SortDesc("id"), rel.Limit(DeliveryLogSize))
+}
@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"time"
+)
+
+// Status of a delivery.
+const (
+	// DeliveryPending is waiting for its next attempt.
+	DeliveryPending = "pending"
+	// DeliverySucceeded is accepted by the receiver.
+	DeliverySucceeded = "succeeded"
+	// DeliveryFailed is given up after MaxAttempts, or because its webhook is disabled.
+	DeliveryFailed = "failed"
+)
+
+// Delivery of an event to a webhook, kept as the delivery log of the webhook.
+type Delivery struct {
+	ID        int    `json:"id"`
+	WebhookID int    `json:"webhook_id"`
+	Event     string `json:"event"`
+	// Payload is the signed request body.
+	Payload  string `json:"payload"`
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+	// NextAttemptAt of pending delivery, it's moved forward while an attempt is in progress so no other worker picks it up.
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	// ResponseStatus and Error of the last attempt.
+	ResponseStatus int       `json:"response_status"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+type enqueue struct {
+	repository rel.Repository
+	now        func() time.Time
+}
+
+// Enqueue delivery of the event to every enabled webhook of its user that matches the event.
+// It's subscribed to published events, deliveries are persisted so they're attempted even after a restart.
+func (e enqueue) Enqueue(ctx context.Context, event events.Event) {
+	if !Supported(event.Name) {
+		return
+	}
+
+	if err := e.enqueue(ctx, event); err != nil {
+		logger.Error("webhook enqueue error", zap.String("event", event.Name), zap.Int("user_id", event.UserID), zap.Error(err))
+	}
+}
+
+func (e enqueue) enqueue(ctx context.Context, event events.Event) error {
+	var (
+		webhooks []Webhook
+		now      = e.now()
+	)
+
+	if err := e.repository.FindAll(ctx, &webhooks, where.Eq("user_id", event.UserID).AndEq("enabled", true)); err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.Events.Match(event.Name) {
+			continue
+		}
+
+		delivery := Delivery{
+			WebhookID:     webhook.ID,
+			Event:         event.Name,
+			Payload:       string(payload),
+			Status:        DeliveryPending,
+			NextAttemptAt: &now,
+		}
+
+		if err := e.repository.Insert(ctx, &delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	webhookNow = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+)
+
+func newService(repository *reltest.Repository) service {
+	service := New(repository, nil).(service)
+	service.enqueue.now = func() time.Time { return webhookNow }
+	service.deliver.now = func() time.Time { return webhookNow }
+	return service
+}
+
+func TestEnqueue(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository)
+		event      = events.Event{Name: "todo.created", UserID: 1, Data: map[string]interface{}{"id": 1}, At: webhookNow}
+		payload    = `{"name":"todo.created","user_id":1,"data":{"id":1},"at":"2026-10-19T09:00:00Z"}`
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1).AndEq("enabled", true)).Result([]Webhook{
+		{ID: 1, UserID: 1, Enabled: true},
+		{ID: 2, UserID: 1, Enabled: true, Events: Filter{"score.changed"}},
+		{ID: 3, UserID: 1, Enabled: true, Events: Filter{"todo.created", "todo.deleted"}},
+	})
+	repository.ExpectInsert().For(&Delivery{WebhookID: 1, Event: "todo.created", Payload: payload, Status: DeliveryPending, NextAttemptAt: &webhookNow})
+	repository.ExpectInsert().For(&Delivery{WebhookID: 3, Event: "todo.created", Payload: payload, Status: DeliveryPending, NextAttemptAt: &webhookNow})
+
+	assert.NotPanics(t, func() {
+		service.Enqueue(ctx, event)
+	})
+
+	repository.AssertExpectations(t)
+}
+
+func TestEnqueue_notMatched(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository)
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1).AndEq("enabled", true)).Result([]Webhook{
+		{ID: 2, UserID: 1, Enabled: true, Events: Filter{"score.changed"}},
+	})
+
+	assert.NotPanics(t, func() {
+		service.Enqueue(ctx, events.Event{Name: "todo.created", UserID: 1})
+	})
+
+	repository.AssertExpectations(t)
+}
+
+func TestEnqueue_unsupported(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository)
+	)
+
+	assert.NotPanics(t, func() {
+		service.Enqueue(ctx, events.Event{Name: "todo.completed", UserID: 1})
+	})
+
+	repository.AssertExpectations(t)
+}
+
+func TestEnqueue_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = newService(repository)
+	)
+
+	repository.ExpectFindAll(where.Eq("user_id", 1).AndEq("enabled", true)).ConnectionClosed()
+
+	assert.NotPanics(t, func() {
+		service.Enqueue(ctx, events.Event{Name: "todo.created", UserID: 1})
+	})
+
+	repository.AssertExpectations(t)
+}
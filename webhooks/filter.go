@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Filter of event names, stored as comma separated string.
+type Filter []string
+
+// Value implements driver.Valuer.
+func (f Filter) Value() (driver.Value, error) {
+	return strings.Join(f, ","), nil
+}
+
+// Scan implements sql.Scanner.
+func (f *Filter) Scan(src interface{}) error {
+	var str string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return fmt.Errorf("webhooks: cannot scan %T into Filter", src)
+	}
+
+	*f = nil
+	if str != "" {
+		*f = strings.Split(str, ",")
+	}
+
+	return nil
+}
+
+// Match returns true if event passes the filter, empty filter matches every event.
+func (f Filter) Match(name string) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for i := range f {
+		if f[i] == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+type search struct {
+	repository rel.Repository
+}
+
+func (s search) Search(ctx context.Context, webhooks *[]Webhook, userID int) error {
+	return s.repository.FindAll(ctx, webhooks, where.Eq("user_id", userID), rel.SortAsc("id"))
+}
@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-rel/gin-example/events"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "webhooks")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output webhookstest --outpkg webhookstest
+
+// Service instance for webhook's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	Search(ctx context.Context, webhooks *[]Webhook, userID int) error
+	Create(ctx context.Context, webhook *Webhook) error
+	Update(ctx context.Context, webhook *Webhook, changes rel.Changeset) error
+	Delete(ctx context.Context, webhook *Webhook)
+	Deliveries(ctx context.Context, deliveries *[]Delivery, webhook Webhook) error
+	Enqueue(ctx context.Context, event events.Event)
+	Deliver(ctx context.Context) error
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	search
+	create
+	update
+	delete
+	deliveries
+	enqueue
+	deliver
+}
+
+var _ Service = (*service)(nil)
+
+// New Webhooks service, Enqueue should be subscribed to published events and Deliver called periodically using Work.
+func New(repository rel.Repository, client *http.Client) Service {
+	return service{
+		search:     search{repository: repository},
+		create:     create{repository: repository},
+		update:     update{repository: repository},
+		delete:     delete{repository: repository},
+		deliveries: deliveries{repository: repository},
+		enqueue:    enqueue{repository: repository, now: time.Now},
+		deliver:    deliver{repository: repository, client: client, now: time.Now},
+	}
+}
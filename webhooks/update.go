@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+type update struct {
+	repository rel.Repository
+}
+
+// Update webhook, enabling a disabled webhook starts counting its failures over.
+func (u update) Update(ctx context.Context, webhook *Webhook, changes rel.Changeset) error {
+	if err := webhook.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	if webhook.Enabled && changes.FieldChanged("enabled") {
+		webhook.Failures = 0
+	}
+
+	return u.repository.Update(ctx, webhook, changes)
+}
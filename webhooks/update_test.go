@@ -0,0 +1,67 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true}
+		changes    = rel.NewChangeset(&webhook)
+	)
+
+	webhook.Events = Filter{"todo.created"}
+
+	repository.ExpectUpdate(changes).ForType("webhooks.Webhook")
+
+	assert.Nil(t, service.Update(ctx, &webhook, changes))
+
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_enabled(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		disabledAt = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Failures: MaxFailures, DisabledAt: &disabledAt}
+		changes    = rel.NewChangeset(&webhook)
+	)
+
+	webhook.Enabled = true
+
+	repository.ExpectUpdate(changes).ForType("webhooks.Webhook")
+
+	assert.Nil(t, service.Update(ctx, &webhook, changes))
+	assert.Zero(t, webhook.Failures)
+	assert.Equal(t, &disabledAt, webhook.DisabledAt)
+	assert.True(t, changes.FieldChanged("failures"))
+
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		webhook    = Webhook{ID: 1, UserID: 1, URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true}
+		changes    = rel.NewChangeset(&webhook)
+	)
+
+	webhook.Events = Filter{"todo.completed"}
+
+	assert.Equal(t, ErrWebhookEventUnsupported, service.Update(ctx, &webhook, changes))
+
+	repository.AssertExpectations(t)
+}
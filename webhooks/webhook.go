@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/go-rel/gin-example/scores"
+	"github.com/go-rel/gin-example/todos"
+)
+
+var (
+	// ErrWebhookURLInvalid validation error.
+	ErrWebhookURLInvalid = errors.New("URL must be an absolute http or https url")
+	// ErrWebhookURLBlocked validation error.
+	ErrWebhookURLBlocked = errors.New("URL must not point to a loopback, private or link-local address")
+	// ErrWebhookSecretShort validation error.
+	ErrWebhookSecretShort = errors.New("Secret must be at least 16 characters")
+	// ErrWebhookEventUnsupported validation error.
+	ErrWebhookEventUnsupported = errors.New("Event is not supported")
+)
+
+// MinSecretLength is the minimum length of secret chosen by the user.
+const MinSecretLength = 16
+
+// Events that can be delivered to webhooks, published after the change is committed.
+var Events = []string{
+	todos.EventTodoCreated,
+	todos.EventTodoUpdated,
+	todos.EventTodoDeleted,
+	todos.EventTodosCleared,
+	scores.EventChanged,
+}
+
+// Supported returns true if event can be delivered to webhooks.
+func Supported(name string) bool {
+	for i := range Events {
+		if Events[i] == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Webhook subscribes an url to events of its user.
+type Webhook struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	URL    string `json:"url"`
+	// Secret signs every delivery, it's only exposed when the webhook is created.
+	Secret string `json:"secret,omitempty"`
+	// Events delivered to the webhook, every supported event is delivered when it's empty.
+	Events  Filter `json:"events"`
+	Enabled bool   `json:"enabled"`
+	// Failures is the number of consecutive failed attempts, the webhook is disabled once it reaches MaxFailures.
+	Failures int `json:"failures"`
+	// DisabledAt is when the webhook was last disabled because of failures, it's kept after the webhook is enabled again.
+	DisabledAt *time.Time `json:"disabled_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Validate webhook.
+func (w Webhook) Validate() error {
+	var err error
+	switch {
+	case !validURL(w.URL):
+		err = ErrWebhookURLInvalid
+	case blockedHost(urlHost(w.URL)):
+		err = ErrWebhookURLBlocked
+	case len(w.Secret) < MinSecretLength:
+		err = ErrWebhookSecretShort
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for i := range w.Events {
+		if !Supported(w.Events[i]) {
+			return ErrWebhookEventUnsupported
+		}
+	}
+
+	return nil
+}
+
+func validURL(str string) bool {
+	u, err := url.Parse(str)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// urlHost without port, url must be valid.
+func urlHost(str string) string {
+	u, _ := url.Parse(str)
+	return u.Hostname()
+}
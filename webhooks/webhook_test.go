@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		err     error
+	}{
+		{
+			name:    "valid",
+			webhook: Webhook{URL: "https://example.com/hooks", Secret: "0123456789abcdef", Events: Filter{"todo.created", "score.changed"}},
+		},
+		{
+			name:    "every event",
+			webhook: Webhook{URL: "http://example.com:8080", Secret: "0123456789abcdef"},
+		},
+		{
+			name:    "localhost",
+			webhook: Webhook{URL: "http://localhost:8080", Secret: "0123456789abcdef"},
+			err:     ErrWebhookURLBlocked,
+		},
+		{
+			name:    "private address",
+			webhook: Webhook{URL: "http://10.0.0.1/hooks", Secret: "0123456789abcdef"},
+			err:     ErrWebhookURLBlocked,
+		},
+		{
+			name:    "link-local address",
+			webhook: Webhook{URL: "http://[fe80::1]/hooks", Secret: "0123456789abcdef"},
+			err:     ErrWebhookURLBlocked,
+		},
+		{
+			name:    "relative url",
+			webhook: Webhook{URL: "/hooks", Secret: "0123456789abcdef"},
+			err:     ErrWebhookURLInvalid,
+		},
+		{
+			name:    "unsupported scheme",
+			webhook: Webhook{URL: "ftp://example.com/hooks", Secret: "0123456789abcdef"},
+			err:     ErrWebhookURLInvalid,
+		},
+		{
+			name:    "short secret",
+			webhook: Webhook{URL: "https://example.com/hooks", Secret: "secret"},
+			err:     ErrWebhookSecretShort,
+		},
+		{
+			name:    "unsupported event",
+			webhook: Webhook{URL: "https://example.com/hooks", Secret: "0123456789abcdef", Events: Filter{"todo.completed"}},
+			err:     ErrWebhookEventUnsupported,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.err, test.webhook.Validate())
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	assert.True(t, Filter(nil).Match("todo.created"))
+	assert.True(t, Filter{"todo.created", "score.changed"}.Match("score.changed"))
+	assert.False(t, Filter{"todo.created"}.Match("todo.deleted"))
+}
+
+func TestFilter_Value(t *testing.T) {
+	value, err := Filter{"todo.created", "score.changed"}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "todo.created,score.changed", value)
+}
+
+func TestFilter_Scan(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    interface{}
+		result Filter
+		err    bool
+	}{
+		{name: "nil", src: nil},
+		{name: "empty", src: ""},
+		{name: "string", src: "todo.created,score.changed", result: Filter{"todo.created", "score.changed"}},
+		{name: "bytes", src: []byte("todo.created"), result: Filter{"todo.created"}},
+		{name: "invalid", src: 1, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var filter Filter
+			err := filter.Scan(test.src)
+			assert.Equal(t, test.err, err != nil)
+			assert.Equal(t, test.result, filter)
+		})
+	}
+}
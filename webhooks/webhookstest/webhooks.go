@@ -0,0 +1,76 @@
+package webhookstest
+
+import (
+	context "context"
+
+	webhooks "github.com/go-rel/gin-example/webhooks"
+	rel "github.com/go-rel/rel"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock webhook functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockSearch util.
+func MockSearch(result []webhooks.Webhook, userID int, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Search", mock.Anything, mock.Anything, userID).
+			Return(func(ctx context.Context, out *[]webhooks.Webhook, userID int) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockCreate util.
+func MockCreate(result webhooks.Webhook, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Create", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *webhooks.Webhook) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockUpdate util.
+func MockUpdate(result webhooks.Webhook, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *webhooks.Webhook, changeset rel.Changeset) error {
+				if result.ID != out.ID {
+					panic("inconsistent id")
+				}
+
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockDelete util.
+func MockDelete() MockFunc {
+	return func(service *Service) {
+		service.On("Delete", mock.Anything, mock.Anything)
+	}
+}
+
+// MockDeliveries util.
+func MockDeliveries(result []webhooks.Delivery, webhook webhooks.Webhook, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Deliveries", mock.Anything, mock.Anything, webhook).
+			Return(func(ctx context.Context, out *[]webhooks.Delivery, webhook webhooks.Webhook) error {
+				*out = result
+				return err
+			})
+	}
+}
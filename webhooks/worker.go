@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultInterval between checks for due deliveries.
+const DefaultInterval = 5 * time.Second
+
+// Work periodically attempts due deliveries, DefaultInterval is used when interval is not positive.
+// It blocks until the context is canceled, deliveries that are due meanwhile are attempted once it's started again.
+func Work(ctx context.Context, service Service, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := service.Deliver(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("webhook deliver error", zap.Error(err))
+			}
+		}
+	}
+}
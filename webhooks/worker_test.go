@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWork(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.TODO())
+		repository  = reltest.New()
+		service     = newService(repository)
+	)
+
+	cancel()
+	assert.NotPanics(t, func() {
+		Work(ctx, service, time.Hour)
+	})
+	repository.AssertExpectations(t)
+}